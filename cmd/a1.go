@@ -21,8 +21,12 @@
 package main
 
 import (
+       "os"
+
+       "gerrit.o-ran-sc.org/r/ric-plt/a1/config"
        "gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
        "gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/restful"
+       "gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/rmr"
 )
 
 func main() {
@@ -35,6 +39,16 @@ func main() {
        // initialize logger
        a1.Init()
 
+       configuration := config.ParseConfiguration()
+       if err := configuration.ValidateEcs(); err != nil {
+              a1.Logger.Error("invalid configuration : %v", err)
+              os.Exit(1)
+       }
+       if err := rmr.RegisterMessageTypes(configuration.RmrMessageTypes); err != nil {
+              a1.Logger.Error("invalid RMR_MESSAGE_TYPES : %v", err)
+              os.Exit(1)
+       }
+
 	// start restful service to handle a1 api's
 	restful := restful.NewRestful()
 
@@ -28,14 +28,52 @@
 )
 
 type Configuration struct {
-	LogLevel          string
-	Name              string
-	MaxSize           int
-	ThreadType        int
-	LowLatency        bool
-	FastAck           bool
-	MaxRetryOnFailure int
-	Port              int
+	LogLevel             string
+	Name                 string
+	MaxSize              int
+	ThreadType           int
+	LowLatency           bool
+	FastAck              bool
+	MaxRetryOnFailure    int
+	Port                 int
+	PolicyAckTimeout     int
+	PolicyDeleteTimeout  int
+	RmrRetryMaxAttempts  int
+	RmrRetryBackoff      int
+	RmrRetryMaxBackoff   int
+	ResyncOnStartup      bool
+	ResyncRate           int
+	PolicyMessageVersion int
+	RmrWorkers           int
+	RmrQueueSize         int
+	RmrMessageTypes      []RmrMessageType
+	RmrTransport         string
+	// LargeMessageStrategy is none, fragment or reference
+	LargeMessageStrategy     string
+	LargeMessageReferenceTTL int
+	PolicyQueryInterval      int
+	HandlerLivenessTimeout   int
+	HandlerLivenessInterval  int
+	PolicyFeedbackSamples    int
+	// EcsURL is the base URL of the enrichment information coordinator
+	EcsURL                   string
+	EcsAPIVersion            string
+	EcsConnectTimeout        int
+	EcsRequestTimeout        int
+	EcsTLSCACert             string
+	EcsTLSCert               string
+	EcsTLSKey                string
+	EcsTLSInsecureSkipVerify bool
+	EcsRetryMaxAttempts      int
+	EcsRetryBackoff          int
+	EcsCircuitFailures       int
+	EcsCircuitOpenTime       int
+}
+
+// RmrMessageType adds an RMR message type or changes the id of a known one
+type RmrMessageType struct {
+	Name string
+	ID   int
 }
 
 func ParseConfiguration() *Configuration {
@@ -65,5 +103,66 @@ func ParseConfiguration() *Configuration {
 	viper.SetDefault("MAX_RETRY_ON_FAILURE", 1)
 	config.Port = viper.GetInt("PORT")
 	viper.SetDefault("PORT", 4562)
+	viper.SetDefault("POLICY_ACK_TIMEOUT", 30)
+	config.PolicyAckTimeout = viper.GetInt("POLICY_ACK_TIMEOUT")
+	viper.SetDefault("POLICY_DELETE_TIMEOUT", 0)
+	config.PolicyDeleteTimeout = viper.GetInt("POLICY_DELETE_TIMEOUT")
+	viper.SetDefault("RMR_RETRY_MAX_ATTEMPTS", 5)
+	config.RmrRetryMaxAttempts = viper.GetInt("RMR_RETRY_MAX_ATTEMPTS")
+	viper.SetDefault("RMR_RETRY_BACKOFF", 1)
+	config.RmrRetryBackoff = viper.GetInt("RMR_RETRY_BACKOFF")
+	viper.SetDefault("RMR_RETRY_MAX_BACKOFF", 60)
+	config.RmrRetryMaxBackoff = viper.GetInt("RMR_RETRY_MAX_BACKOFF")
+	viper.SetDefault("RESYNC_ON_STARTUP", false)
+	config.ResyncOnStartup = viper.GetBool("RESYNC_ON_STARTUP")
+	viper.SetDefault("RESYNC_RATE", 10)
+	config.ResyncRate = viper.GetInt("RESYNC_RATE")
+	viper.SetDefault("POLICY_MESSAGE_VERSION", 2)
+	config.PolicyMessageVersion = viper.GetInt("POLICY_MESSAGE_VERSION")
+	viper.SetDefault("RMR_WORKERS", 4)
+	config.RmrWorkers = viper.GetInt("RMR_WORKERS")
+	viper.SetDefault("RMR_QUEUE_SIZE", 100)
+	config.RmrQueueSize = viper.GetInt("RMR_QUEUE_SIZE")
+	viper.SetDefault("RMR_TRANSPORT", "rmr")
+	config.RmrTransport = viper.GetString("RMR_TRANSPORT")
+	viper.SetDefault("LARGE_MESSAGE_STRATEGY", "fragment")
+	config.LargeMessageStrategy = viper.GetString("LARGE_MESSAGE_STRATEGY")
+	viper.SetDefault("LARGE_MESSAGE_REFERENCE_TTL", 300)
+	config.LargeMessageReferenceTTL = viper.GetInt("LARGE_MESSAGE_REFERENCE_TTL")
+	viper.SetDefault("POLICY_QUERY_INTERVAL", 10)
+	config.PolicyQueryInterval = viper.GetInt("POLICY_QUERY_INTERVAL")
+	viper.SetDefault("HANDLER_LIVENESS_TIMEOUT", 0)
+	config.HandlerLivenessTimeout = viper.GetInt("HANDLER_LIVENESS_TIMEOUT")
+	viper.SetDefault("HANDLER_LIVENESS_INTERVAL", 10)
+	config.HandlerLivenessInterval = viper.GetInt("HANDLER_LIVENESS_INTERVAL")
+	viper.SetDefault("POLICY_FEEDBACK_SAMPLES", 100)
+	config.PolicyFeedbackSamples = viper.GetInt("POLICY_FEEDBACK_SAMPLES")
+	if config.PolicyFeedbackSamples <= 0 {
+		a1.Logger.Error("#configuration.ParseConfiguration - invalid POLICY_FEEDBACK_SAMPLES %d, keeping 100 samples\n", config.PolicyFeedbackSamples)
+		config.PolicyFeedbackSamples = 100
+	}
+	viper.SetDefault("ECS_URL", "http://ecs-service:8083")
+	config.EcsURL = viper.GetString("ECS_URL")
+	viper.SetDefault("ECS_API_VERSION", "v1")
+	config.EcsAPIVersion = viper.GetString("ECS_API_VERSION")
+	viper.SetDefault("ECS_CONNECT_TIMEOUT", 5)
+	config.EcsConnectTimeout = viper.GetInt("ECS_CONNECT_TIMEOUT")
+	viper.SetDefault("ECS_REQUEST_TIMEOUT", 10)
+	config.EcsRequestTimeout = viper.GetInt("ECS_REQUEST_TIMEOUT")
+	config.EcsTLSCACert = viper.GetString("ECS_TLS_CA_CERT")
+	config.EcsTLSCert = viper.GetString("ECS_TLS_CERT")
+	config.EcsTLSKey = viper.GetString("ECS_TLS_KEY")
+	config.EcsTLSInsecureSkipVerify = viper.GetBool("ECS_TLS_INSECURE_SKIP_VERIFY")
+	viper.SetDefault("ECS_RETRY_MAX_ATTEMPTS", 3)
+	config.EcsRetryMaxAttempts = viper.GetInt("ECS_RETRY_MAX_ATTEMPTS")
+	viper.SetDefault("ECS_RETRY_BACKOFF", 1)
+	config.EcsRetryBackoff = viper.GetInt("ECS_RETRY_BACKOFF")
+	viper.SetDefault("ECS_CIRCUIT_FAILURES", 5)
+	config.EcsCircuitFailures = viper.GetInt("ECS_CIRCUIT_FAILURES")
+	viper.SetDefault("ECS_CIRCUIT_OPEN_TIME", 30)
+	config.EcsCircuitOpenTime = viper.GetInt("ECS_CIRCUIT_OPEN_TIME")
+	if err := viper.UnmarshalKey("RMR_MESSAGE_TYPES", &config.RmrMessageTypes); err != nil {
+		a1.Logger.Error("#configuration.ParseConfiguration - invalid RMR_MESSAGE_TYPES: %s\n", err)
+	}
 	return &config
 }
@@ -0,0 +1,73 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+)
+
+var ecsAPIVersionPattern = regexp.MustCompile(`^v[0-9]+$`)
+
+// ValidateEcs checks the settings of the enrichment information coordinator,
+// the mediator does not start with an invalid one
+func (c *Configuration) ValidateEcs() error {
+	ecsURL, err := url.Parse(c.EcsURL)
+	if err != nil {
+		return fmt.Errorf("invalid ECS_URL %q : %v", c.EcsURL, err)
+	}
+	if (ecsURL.Scheme != "http" && ecsURL.Scheme != "https") || len(ecsURL.Host) == 0 {
+		return fmt.Errorf("invalid ECS_URL %q : expecting an http or https URL with a host", c.EcsURL)
+	}
+	if len(ecsURL.RawQuery) > 0 || len(ecsURL.Fragment) > 0 {
+		return fmt.Errorf("invalid ECS_URL %q : no query or fragment expected", c.EcsURL)
+	}
+	if !ecsAPIVersionPattern.MatchString(c.EcsAPIVersion) {
+		return fmt.Errorf("invalid ECS_API_VERSION %q : expecting a version such as v1", c.EcsAPIVersion)
+	}
+	if c.EcsConnectTimeout <= 0 || c.EcsRequestTimeout <= 0 {
+		return fmt.Errorf("invalid ECS_CONNECT_TIMEOUT %d or ECS_REQUEST_TIMEOUT %d : expecting a number of seconds", c.EcsConnectTimeout, c.EcsRequestTimeout)
+	}
+	if c.EcsRetryMaxAttempts < 1 || c.EcsRetryBackoff < 0 {
+		return fmt.Errorf("invalid ECS_RETRY_MAX_ATTEMPTS %d or ECS_RETRY_BACKOFF %d : expecting at least one attempt", c.EcsRetryMaxAttempts, c.EcsRetryBackoff)
+	}
+	if c.EcsCircuitFailures < 0 || (c.EcsCircuitFailures > 0 && c.EcsCircuitOpenTime <= 0) {
+		return fmt.Errorf("invalid ECS_CIRCUIT_FAILURES %d or ECS_CIRCUIT_OPEN_TIME %d", c.EcsCircuitFailures, c.EcsCircuitOpenTime)
+	}
+	if (len(c.EcsTLSCert) == 0) != (len(c.EcsTLSKey) == 0) {
+		return fmt.Errorf("ECS_TLS_CERT and ECS_TLS_KEY must be set together")
+	}
+	tlsSet := len(c.EcsTLSCACert) > 0 || len(c.EcsTLSCert) > 0 || c.EcsTLSInsecureSkipVerify
+	if tlsSet && ecsURL.Scheme != "https" {
+		return fmt.Errorf("ECS TLS settings need an https ECS_URL, got %q", c.EcsURL)
+	}
+	for _, file := range []string{c.EcsTLSCACert, c.EcsTLSCert, c.EcsTLSKey} {
+		if len(file) == 0 {
+			continue
+		}
+		if _, err := os.Stat(file); err != nil {
+			return fmt.Errorf("invalid ECS TLS file : %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func validEcsConfiguration() *Configuration {
+	return &Configuration{
+		EcsURL:              "http://ecs-service:8083",
+		EcsAPIVersion:       "v1",
+		EcsConnectTimeout:   5,
+		EcsRequestTimeout:   10,
+		EcsRetryMaxAttempts: 3,
+		EcsRetryBackoff:     1,
+		EcsCircuitFailures:  5,
+		EcsCircuitOpenTime:  30,
+	}
+}
+
+func TestValidateEcs(t *testing.T) {
+	assert.Nil(t, validEcsConfiguration().ValidateEcs())
+
+	conf := validEcsConfiguration()
+	conf.EcsURL = "https://ecs-service:8443/"
+	conf.EcsTLSInsecureSkipVerify = true
+	assert.Nil(t, conf.ValidateEcs())
+}
+
+func TestValidateEcsFail(t *testing.T) {
+	for _, change := range []func(conf *Configuration){
+		func(conf *Configuration) { conf.EcsURL = "ecs-service:8083" },
+		func(conf *Configuration) { conf.EcsURL = "ftp://ecs-service" },
+		func(conf *Configuration) { conf.EcsURL = "http://ecs-service:8083?a=b" },
+		func(conf *Configuration) { conf.EcsAPIVersion = "1" },
+		func(conf *Configuration) { conf.EcsRequestTimeout = 0 },
+		func(conf *Configuration) { conf.EcsRetryMaxAttempts = 0 },
+		func(conf *Configuration) { conf.EcsCircuitOpenTime = 0 },
+		func(conf *Configuration) { conf.EcsTLSInsecureSkipVerify = true },
+		func(conf *Configuration) { conf.EcsURL = "https://ecs-service"; conf.EcsTLSCert = "/tmp/cert.pem" },
+		func(conf *Configuration) {
+			conf.EcsURL = "https://ecs-service"
+			conf.EcsTLSCACert = "/nonexistent/ca.pem"
+		},
+	} {
+		conf := validEcsConfiguration()
+		change(conf)
+		assert.NotNil(t, conf.ValidateEcs(), "%+v", conf)
+	}
+}
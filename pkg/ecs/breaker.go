@@ -0,0 +1,82 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package ecs
+
+import (
+	"sync"
+	"time"
+)
+
+// circuitBreaker stops calling ECS for openTime once threshold requests failed
+// in a row. A single trial request is then let through, closing the circuit
+// again when it succeeds. A threshold of 0 never opens the circuit.
+type circuitBreaker struct {
+	mutex     sync.Mutex
+	threshold int
+	openTime  time.Duration
+	failures  int
+	openedAt  time.Time
+	trial     bool
+}
+
+func newCircuitBreaker(threshold int, openTime time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, openTime: openTime}
+}
+
+func (b *circuitBreaker) open() bool {
+	return b.threshold > 0 && b.failures >= b.threshold
+}
+
+// allow tells whether a request may be sent to ECS
+func (b *circuitBreaker) allow(now time.Time) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if !b.open() {
+		return true
+	}
+	if b.trial || now.Sub(b.openedAt) < b.openTime {
+		return false
+	}
+	b.trial = true
+	return true
+}
+
+func (b *circuitBreaker) success() {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.failures = 0
+	b.trial = false
+}
+
+// failure records a failed request and tells whether it opened the circuit
+func (b *circuitBreaker) failure(now time.Time) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	wasOpen := b.open()
+	b.failures++
+	b.trial = false
+	if !b.open() {
+		return false
+	}
+	b.openedAt = now
+	return !wasOpen
+}
@@ -0,0 +1,58 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package ecs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	breaker := newCircuitBreaker(2, 30*time.Second)
+	now := time.Now()
+	assert.True(t, breaker.allow(now))
+	assert.False(t, breaker.failure(now))
+	assert.True(t, breaker.failure(now))
+	assert.False(t, breaker.allow(now.Add(10*time.Second)))
+
+	// a single trial once the circuit was open long enough
+	assert.True(t, breaker.allow(now.Add(30*time.Second)))
+	assert.False(t, breaker.allow(now.Add(30*time.Second)))
+	assert.False(t, breaker.failure(now.Add(31*time.Second)))
+	assert.False(t, breaker.allow(now.Add(40*time.Second)))
+
+	assert.True(t, breaker.allow(now.Add(61*time.Second)))
+	breaker.success()
+	assert.True(t, breaker.allow(now.Add(61*time.Second)))
+	assert.True(t, breaker.allow(now.Add(61*time.Second)))
+}
+
+func TestCircuitBreakerDisabled(t *testing.T) {
+	breaker := newCircuitBreaker(0, 0)
+	now := time.Now()
+	for i := 0; i < 10; i++ {
+		assert.False(t, breaker.failure(now))
+	}
+	assert.True(t, breaker.allow(now))
+}
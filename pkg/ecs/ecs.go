@@ -0,0 +1,234 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package ecs
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/config"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/metrics"
+)
+
+// ErrCircuitOpen is returned without calling ECS while it is considered down
+var ErrCircuitOpen = errors.New("ECS is unavailable, circuit breaker open")
+
+// StatusError is a request ECS answered with an unexpected status
+type StatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("ECS answered with status %d : %s", e.StatusCode, e.Body)
+}
+
+// StatusCode returns the HTTP status ECS answered the failed request with, or
+// 503 when ECS could not be reached
+func StatusCode(err error) int {
+	var statusError *StatusError
+	if errors.As(err, &statusError) {
+		return statusError.StatusCode
+	}
+	return http.StatusServiceUnavailable
+}
+
+// Client calls the A1-EI API of the enrichment information coordinator. A
+// request that fails or gets a 5xx status is sent again up to maxAttempts times,
+// and ECS is not called for a while after repeated failures.
+type Client struct {
+	apiURL      string
+	http        *http.Client
+	maxAttempts int
+	backoff     time.Duration
+	breaker     *circuitBreaker
+}
+
+// NewClient returns the ECS client of the configuration, after checking it
+func NewClient(conf *config.Configuration) (*Client, error) {
+	if err := conf.ValidateEcs(); err != nil {
+		return nil, err
+	}
+	tlsConfig, err := tlsConfig(conf)
+	if err != nil {
+		return nil, err
+	}
+	connectTimeout := time.Duration(conf.EcsConnectTimeout) * time.Second
+	transport := &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		DialContext:         (&net.Dialer{Timeout: connectTimeout}).DialContext,
+		TLSHandshakeTimeout: connectTimeout,
+		TLSClientConfig:     tlsConfig,
+	}
+	return &Client{
+		apiURL: strings.TrimSuffix(conf.EcsURL, "/") + "/A1-EI/" + conf.EcsAPIVersion,
+		http: &http.Client{
+			Transport: transport,
+			Timeout:   time.Duration(conf.EcsRequestTimeout) * time.Second,
+		},
+		maxAttempts: conf.EcsRetryMaxAttempts,
+		backoff:     time.Duration(conf.EcsRetryBackoff) * time.Second,
+		breaker:     newCircuitBreaker(conf.EcsCircuitFailures, time.Duration(conf.EcsCircuitOpenTime)*time.Second),
+	}, nil
+}
+
+// tlsConfig returns the TLS settings of an https ECS_URL, or none for http
+func tlsConfig(conf *config.Configuration) (*tls.Config, error) {
+	if !strings.HasPrefix(conf.EcsURL, "https:") {
+		return nil, nil
+	}
+	tlsConfig := &tls.Config{InsecureSkipVerify: conf.EcsTLSInsecureSkipVerify}
+	if len(conf.EcsTLSCACert) > 0 {
+		caCert, err := ioutil.ReadFile(conf.EcsTLSCACert)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no certificate found in ECS_TLS_CA_CERT %s", conf.EcsTLSCACert)
+		}
+	}
+	if len(conf.EcsTLSCert) > 0 {
+		cert, err := tls.LoadX509KeyPair(conf.EcsTLSCert, conf.EcsTLSKey)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+func (c *Client) eiTypesURL() string {
+	return c.apiURL + "/eitypes"
+}
+
+func (c *Client) eiJobURL(jobId string) string {
+	return c.apiURL + "/eijobs/" + jobId
+}
+
+// GetEiTypes returns the EI types known by ECS as sent by ECS
+func (c *Client) GetEiTypes() ([]byte, error) {
+	return c.do(http.MethodGet, c.eiTypesURL(), nil)
+}
+
+// PutEiJob creates or updates an EI job in ECS
+func (c *Client) PutEiJob(jobId string, job []byte) ([]byte, error) {
+	return c.do(http.MethodPut, c.eiJobURL(jobId), job)
+}
+
+// GetEiJob returns the definition of an EI job as sent by ECS
+func (c *Client) GetEiJob(jobId string) ([]byte, error) {
+	return c.do(http.MethodGet, c.eiJobURL(jobId), nil)
+}
+
+// GetEiJobStatus returns the status of an EI job as sent by ECS
+func (c *Client) GetEiJobStatus(jobId string) ([]byte, error) {
+	return c.do(http.MethodGet, c.eiJobURL(jobId)+"/status", nil)
+}
+
+// DeleteEiJob deletes an EI job in ECS
+func (c *Client) DeleteEiJob(jobId string) error {
+	_, err := c.do(http.MethodDelete, c.eiJobURL(jobId), nil)
+	return err
+}
+
+// GetEiJobIds returns the ids of the EI jobs of an owner as sent by ECS,
+// restricted to one EI type unless eiTypeId is empty
+func (c *Client) GetEiJobIds(owner string, eiTypeId string) ([]byte, error) {
+	query := url.Values{}
+	query.Set("owner", owner)
+	if len(eiTypeId) > 0 {
+		query.Set("eiTypeId", eiTypeId)
+	}
+	return c.do(http.MethodGet, c.apiURL+"/eijobs?"+query.Encode(), nil)
+}
+
+// do sends the request until ECS answers it without a 5xx status or the
+// attempts are exhausted. The delay before a retry doubles after every attempt.
+func (c *Client) do(method string, url string, body []byte) ([]byte, error) {
+	delay := c.backoff
+	var err error
+	for attempt := 1; attempt <= c.maxAttempts; attempt++ {
+		if attempt > 1 {
+			metrics.IncCounter(metrics.EcsRequestRetry)
+			time.Sleep(delay)
+			delay *= 2
+		}
+		if !c.breaker.allow(time.Now()) {
+			err = ErrCircuitOpen
+			break
+		}
+		var respBody []byte
+		var retry bool
+		respBody, retry, err = c.send(method, url, body)
+		if !retry {
+			c.breaker.success()
+			return respBody, err
+		}
+		a1.Logger.Warning("ECS request %s %s failed, attempt %d of %d : %v", method, url, attempt, c.maxAttempts, err)
+		if c.breaker.failure(time.Now()) {
+			a1.Logger.Error("ECS failed %d times in a row, not calling it for %v", c.breaker.threshold, c.breaker.openTime)
+			metrics.IncCounter(metrics.EcsCircuitOpen)
+			break
+		}
+	}
+	metrics.IncCounter(metrics.EcsRequestFailure)
+	return nil, err
+}
+
+// send sends the request once and tells whether it may be sent again
+func (c *Client) send(method string, url string, body []byte) ([]byte, bool, error) {
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, false, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, true, err
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, err
+	}
+	a1.Logger.Debug("ECS answered %s %s with status %d", method, url, resp.StatusCode)
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return nil, true, &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return respBody, false, &StatusError{StatusCode: resp.StatusCode, Body: string(respBody)}
+	}
+	return respBody, false, nil
+}
@@ -0,0 +1,175 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package ecs
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/config"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMain(m *testing.M) {
+	a1.Init()
+	os.Exit(m.Run())
+}
+
+func testConfiguration(url string) *config.Configuration {
+	return &config.Configuration{
+		EcsURL:              url,
+		EcsAPIVersion:       "v1",
+		EcsConnectTimeout:   1,
+		EcsRequestTimeout:   1,
+		EcsRetryMaxAttempts: 3,
+		EcsCircuitFailures:  5,
+		EcsCircuitOpenTime:  30,
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	conf := testConfiguration("http://ecs-stub:9083/")
+	conf.EcsAPIVersion = "v2"
+	client, err := NewClient(conf)
+	assert.Nil(t, err)
+	assert.Equal(t, "http://ecs-stub:9083/A1-EI/v2/eitypes", client.eiTypesURL())
+	assert.Equal(t, "http://ecs-stub:9083/A1-EI/v2/eijobs/12", client.eiJobURL("12"))
+	assert.Equal(t, time.Second, client.http.Timeout)
+
+	conf.EcsAPIVersion = ""
+	_, err = NewClient(conf)
+	assert.NotNil(t, err)
+}
+
+func TestNewClientTLS(t *testing.T) {
+	caCert := filepath.Join(t.TempDir(), "ca.pem")
+	assert.Nil(t, ioutil.WriteFile(caCert, []byte("not a certificate"), os.ModePerm))
+	conf := testConfiguration("https://ecs-service:8443")
+	conf.EcsTLSInsecureSkipVerify = true
+	tlsConfig, err := tlsConfig(conf)
+	assert.Nil(t, err)
+	assert.True(t, tlsConfig.InsecureSkipVerify)
+
+	conf.EcsTLSCACert = caCert
+	_, err = NewClient(conf)
+	assert.NotNil(t, err)
+}
+
+func TestGetEiTypesRetry(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, "/A1-EI/v1/eitypes", r.URL.Path)
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`["type1"]`))
+	}))
+	defer server.Close()
+	client, err := NewClient(testConfiguration(server.URL))
+	assert.Nil(t, err)
+
+	body, err := client.GetEiTypes()
+	assert.Nil(t, err)
+	assert.Equal(t, `["type1"]`, string(body))
+	assert.Equal(t, 3, calls)
+}
+
+func TestPutEiJobRejected(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/A1-EI/v1/eijobs/12", r.URL.Path)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+	client, err := NewClient(testConfiguration(server.URL))
+	assert.Nil(t, err)
+
+	_, err = client.PutEiJob("12", []byte(`{"job-id":12}`))
+	assert.Equal(t, http.StatusBadRequest, StatusCode(err))
+	assert.Equal(t, 1, calls)
+}
+
+func TestGetEiJobIds(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/A1-EI/v1/eijobs", r.URL.Path)
+		assert.Equal(t, "xapp1", r.URL.Query().Get("owner"))
+		assert.Equal(t, "type1", r.URL.Query().Get("eiTypeId"))
+		w.Write([]byte(`["12","13"]`))
+	}))
+	defer server.Close()
+	client, err := NewClient(testConfiguration(server.URL))
+	assert.Nil(t, err)
+
+	body, err := client.GetEiJobIds("xapp1", "type1")
+	assert.Nil(t, err)
+	assert.Equal(t, `["12","13"]`, string(body))
+}
+
+func TestDeleteEiJob(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		if r.URL.Path != "/A1-EI/v1/eijobs/12" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	client, err := NewClient(testConfiguration(server.URL))
+	assert.Nil(t, err)
+
+	assert.Nil(t, client.DeleteEiJob("12"))
+	assert.Equal(t, http.StatusNotFound, StatusCode(client.DeleteEiJob("13")))
+}
+
+func TestCircuitOpen(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	conf := testConfiguration(server.URL)
+	conf.EcsCircuitFailures = 2
+	client, err := NewClient(conf)
+	assert.Nil(t, err)
+
+	_, err = client.GetEiTypes()
+	assert.Equal(t, http.StatusInternalServerError, StatusCode(err))
+	assert.Equal(t, 2, calls)
+
+	_, err = client.GetEiTypes()
+	assert.Equal(t, ErrCircuitOpen, err)
+	assert.Equal(t, http.StatusServiceUnavailable, StatusCode(err))
+	assert.Equal(t, 2, calls)
+}
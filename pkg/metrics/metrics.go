@@ -0,0 +1,134 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package metrics
+
+import (
+	"sync"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/xapp-frame/pkg/xapp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	a1MetricsNamespace   = "ricxapp"
+	a1MetricsSubsystem   = "A1"
+	PolicyAckTimeout     = "PolicyAckTimeout"
+	PolicyDeleteTimeout  = "PolicyDeleteTimeout"
+	RmrSendRetry         = "RmrSendRetry"
+	RmrDeadLetter        = "RmrDeadLetter"
+	StalePolicyResponse  = "StalePolicyResponse"
+	InvalidRmrMessage    = "InvalidRmrMessage"
+	UnknownRmrMessage    = "UnknownRmrMessage"
+	OversizedRmrMessage  = "OversizedRmrMessage"
+	FragmentedRmrMessage = "FragmentedRmrMessage"
+	ReferencedRmrMessage = "ReferencedRmrMessage"
+	ThrottledPolicyQuery = "ThrottledPolicyQuery"
+	LostPolicyHandler    = "LostPolicyHandler"
+	EcsRequestRetry      = "EcsRequestRetry"
+	EcsRequestFailure    = "EcsRequestFailure"
+	EcsCircuitOpen       = "EcsCircuitOpen"
+	RmrQueueDepth        = "RmrQueueDepth"
+	RmrQueueFull         = "RmrQueueFull"
+	RmrProcessingLatency = "RmrProcessingLatency"
+)
+
+var counterOpts = []xapp.CounterOpts{
+	{Name: PolicyAckTimeout, Help: "The total number of policy requests not acknowledged by any xApp in time"},
+	{Name: PolicyDeleteTimeout, Help: "The total number of policy deletions not acknowledged by the xApps in time"},
+	{Name: RmrSendRetry, Help: "The total number of RMR messages sent again after a failure"},
+	{Name: RmrDeadLetter, Help: "The total number of RMR messages given up after all retries"},
+	{Name: StalePolicyResponse, Help: "The total number of policy responses ignored because they answer an older request"},
+	{Name: InvalidRmrMessage, Help: "The total number of RMR messages from the xApps rejected as invalid"},
+	{Name: UnknownRmrMessage, Help: "The total number of RMR messages from the xApps discarded because of an unknown message type"},
+	{Name: OversizedRmrMessage, Help: "The total number of RMR messages not sent because they are over the maximum size"},
+	{Name: FragmentedRmrMessage, Help: "The total number of RMR messages over the maximum size sent in fragments"},
+	{Name: ReferencedRmrMessage, Help: "The total number of RMR messages over the maximum size sent as a reference to SDL"},
+	{Name: ThrottledPolicyQuery, Help: "The total number of policy queries ignored because an xApp repeated them too often"},
+	{Name: LostPolicyHandler, Help: "The total number of policy type handlers lost because they stopped sending heartbeats"},
+	{Name: EcsRequestRetry, Help: "The total number of ECS requests sent again after a failure"},
+	{Name: EcsRequestFailure, Help: "The total number of ECS requests given up because ECS was unavailable"},
+	{Name: EcsCircuitOpen, Help: "The total number of times ECS stopped being called after repeated failures"},
+	{Name: RmrQueueFull, Help: "The total number of received RMR messages dropped because the queue of their worker was full"},
+}
+
+var gaugeOpts = []xapp.CounterOpts{
+	{Name: RmrQueueDepth, Help: "The number of received RMR messages waiting to be processed"},
+}
+
+// xapp-frame only registers counters and gauges, the histograms are registered
+// directly under the same namespace and subsystem
+var histogramOpts = []prometheus.HistogramOpts{
+	{Name: RmrProcessingLatency, Help: "The milliseconds between the arrival and the end of processing of the received RMR messages", Buckets: prometheus.ExponentialBuckets(1, 2, 14)},
+}
+
+var (
+	once       sync.Once
+	counters   map[string]xapp.Counter
+	gauges     map[string]xapp.Gauge
+	histograms map[string]prometheus.Histogram
+)
+
+func register() {
+	once.Do(func() {
+		counters = xapp.Metric.RegisterCounterGroup(counterOpts, a1MetricsSubsystem)
+		gauges = xapp.Metric.RegisterGaugeGroup(gaugeOpts, a1MetricsSubsystem)
+		histograms = make(map[string]prometheus.Histogram)
+		for _, opts := range histogramOpts {
+			opts.Namespace = a1MetricsNamespace
+			opts.Subsystem = a1MetricsSubsystem
+			histograms[opts.Name] = promauto.NewHistogram(opts)
+		}
+	})
+}
+
+// IncCounter increments the named A1 counter
+func IncCounter(name string) {
+	register()
+	if counter, ok := counters[name]; ok {
+		counter.Inc()
+	}
+}
+
+// SetGauge sets the named A1 gauge
+func SetGauge(name string, value float64) {
+	register()
+	if gauge, ok := gauges[name]; ok {
+		gauge.Set(value)
+	}
+}
+
+// AddGauge adds delta to the named A1 gauge
+func AddGauge(name string, delta float64) {
+	register()
+	if gauge, ok := gauges[name]; ok {
+		gauge.Add(delta)
+	}
+}
+
+// Observe records value in the named A1 histogram
+func Observe(name string, value float64) {
+	register()
+	if histogram, ok := histograms[name]; ok {
+		histogram.Observe(value)
+	}
+}
@@ -0,0 +1,101 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package policy
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/models"
+)
+
+// maxPolicyFeedbackSamples is the most feedback samples kept for an instance,
+// whatever POLICY_FEEDBACK_SAMPLES is set to
+const maxPolicyFeedbackSamples = 1000
+
+// ParsePolicyFeedback decodes the feedback samples of an instance stored in SDL
+func ParsePolicyFeedback(data interface{}) ([]PolicyFeedback, error) {
+	var samples []PolicyFeedback
+	str, ok := data.(string)
+	if !ok || len(str) == 0 {
+		return samples, nil
+	}
+	if err := json.Unmarshal([]byte(str), &samples); err != nil {
+		return nil, err
+	}
+	return samples, nil
+}
+
+// PolicyFeedbackList converts the feedback samples to the REST model, oldest first
+func PolicyFeedbackList(samples []PolicyFeedback) []*models.PolicyFeedback {
+	list := make([]*models.PolicyFeedback, 0, len(samples))
+	for _, sample := range samples {
+		list = append(list, &models.PolicyFeedback{
+			HandlerID:  sample.HandlerID,
+			RanName:    sample.RanName,
+			Kpis:       sample.Kpis,
+			MeasuredAt: sample.MeasuredAt,
+			ReceivedAt: sample.ReceivedAt,
+		})
+	}
+	return list
+}
+
+// RecordPolicyFeedback adds a feedback sample to the instance, keeping only the
+// last maxSamples samples, and never more than maxPolicyFeedbackSamples
+func (pm *PolicyManager) RecordPolicyFeedback(policyTypeId int, policyInstanceID string, feedback PolicyFeedback, maxSamples int) error {
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+	suffix := strconv.FormatInt((int64(policyTypeId)), 10) + "." + policyInstanceID
+	instanceKey := a1InstancePrefix + suffix
+	feedbackKey := a1FeedbackPrefix + suffix
+	valmap, err := pm.db.Get(a1MediatorNs, []string{instanceKey, feedbackKey})
+	if err != nil {
+		a1.Logger.Error("error in retrieving policy feedback err: %v", err)
+		return err
+	}
+	if valmap[instanceKey] == nil {
+		a1.Logger.Debug("policy instance Not Present for policyinstaneid : %v", policyInstanceID)
+		return policyInstanceNotFoundError
+	}
+	samples, err := ParsePolicyFeedback(valmap[feedbackKey])
+	if err != nil {
+		a1.Logger.Error("unmarshal error : %v", err)
+		samples = nil
+	}
+	feedback.ReceivedAt = time.Now().Format("2006-01-02 15:04:05")
+	samples = append(samples, feedback)
+	if maxSamples <= 0 || maxSamples > maxPolicyFeedbackSamples {
+		maxSamples = maxPolicyFeedbackSamples
+	}
+	if len(samples) > maxSamples {
+		samples = samples[len(samples)-maxSamples:]
+	}
+	data, err := json.Marshal(samples)
+	if err != nil {
+		a1.Logger.Error("marshal error : %v", err)
+		return err
+	}
+	return pm.db.Set(a1MediatorNs, feedbackKey, string(data))
+}
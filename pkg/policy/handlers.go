@@ -0,0 +1,133 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package policy
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/models"
+)
+
+// ParsePolicyTypeHandlers decodes the handlers of a policy type stored in SDL
+func ParsePolicyTypeHandlers(data interface{}) (map[string]PolicyTypeHandler, error) {
+	handlers := map[string]PolicyTypeHandler{}
+	str, ok := data.(string)
+	if !ok || len(str) == 0 {
+		return handlers, nil
+	}
+	if err := json.Unmarshal([]byte(str), &handlers); err != nil {
+		return nil, err
+	}
+	return handlers, nil
+}
+
+// PolicyTypeHandlerList returns the handlers of a policy type sorted by id
+func PolicyTypeHandlerList(handlers map[string]PolicyTypeHandler) []*models.PolicyTypeHandler {
+	list := make([]*models.PolicyTypeHandler, 0, len(handlers))
+	for id, handler := range handlers {
+		list = append(list, &models.PolicyTypeHandler{
+			HandlerID:     id,
+			FirstSeen:     handler.FirstSeen,
+			LastSeen:      handler.LastSeen,
+			LastStatus:    handler.LastStatus,
+			LastHeartbeat: handler.LastHeartbeat,
+			Lost:          handler.Lost,
+		})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].HandlerID < list[j].HandlerID })
+	return list
+}
+
+// RecordPolicyTypeHandler records that a handler reported a status for an
+// instance of the policy type. Nothing is recorded for a policy type that does
+// not exist.
+func (pm *PolicyManager) RecordPolicyTypeHandler(policyTypeId int, handlerId string, status string) error {
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+	exists, err := pm.policyTypeExists(policyTypeId)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		a1.Logger.Debug("policy type Not Present for policyid : %v", policyTypeId)
+		return policyTypeNotFoundError
+	}
+	handlers, err := pm.getPolicyTypeHandlers(policyTypeId)
+	if err != nil {
+		return err
+	}
+	now := time.Now().Format("2006-01-02 15:04:05")
+	handler, known := handlers[handlerId]
+	if !known {
+		a1.Logger.Info("policy type %d is handled by %s", policyTypeId, handlerId)
+		handler.FirstSeen = now
+	}
+	if handler.Lost {
+		a1.Logger.Info("handler %s of policy type %d is back", handlerId, policyTypeId)
+		handler.Lost = false
+	}
+	handler.LastSeen = now
+	handler.LastStatus = status
+	handlers[handlerId] = handler
+	return pm.setPolicyTypeHandlers(policyTypeId, handlers)
+}
+
+// policyTypeExists tells whether the policy type is stored, the handlers of a
+// type are only recorded while it exists
+func (pm *PolicyManager) policyTypeExists(policyTypeId int) (bool, error) {
+	typekey := a1PolicyPrefix + strconv.FormatInt((int64(policyTypeId)), 10)
+	valmap, err := pm.db.Get(a1MediatorNs, []string{typekey})
+	if err != nil {
+		a1.Logger.Error("error in retrieving policy type. err: %v", err)
+		return false, err
+	}
+	return valmap[typekey] != nil, nil
+}
+
+func (pm *PolicyManager) getPolicyTypeHandlers(policyTypeId int) (map[string]PolicyTypeHandler, error) {
+	typeHandlerKey := a1TypeHandlerPrefix + strconv.FormatInt((int64(policyTypeId)), 10)
+	resp, err := pm.db.Get(a1MediatorNs, []string{typeHandlerKey})
+	if err != nil {
+		a1.Logger.Error("error1 :%+v", err)
+		return nil, err
+	}
+	handlers, err := ParsePolicyTypeHandlers(resp[typeHandlerKey])
+	if err != nil {
+		a1.Logger.Error("unmarshal error : %v", err)
+		return nil, err
+	}
+	return handlers, nil
+}
+
+func (pm *PolicyManager) setPolicyTypeHandlers(policyTypeId int, handlers map[string]PolicyTypeHandler) error {
+	typeHandlerKey := a1TypeHandlerPrefix + strconv.FormatInt((int64(policyTypeId)), 10)
+	data, err := json.Marshal(handlers)
+	if err != nil {
+		a1.Logger.Error("marshal error : %v", err)
+		return err
+	}
+	return pm.db.Set(a1MediatorNs, typeHandlerKey, string(data))
+}
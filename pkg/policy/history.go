@@ -0,0 +1,91 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package policy
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/models"
+)
+
+// maxStatusHistory is the number of status changes kept for an instance, the
+// oldest changes are dropped first
+const maxStatusHistory = 100
+
+// ParseStatusHistory decodes the status history of an instance stored in SDL
+func ParseStatusHistory(data interface{}) ([]StatusChange, error) {
+	var history []StatusChange
+	str, ok := data.(string)
+	if !ok || len(str) == 0 {
+		return history, nil
+	}
+	if err := json.Unmarshal([]byte(str), &history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// StatusHistoryList converts the status history to the REST model, oldest first
+func StatusHistoryList(history []StatusChange) []*models.PolicyStatusChange {
+	list := make([]*models.PolicyStatusChange, 0, len(history))
+	for _, change := range history {
+		list = append(list, &models.PolicyStatusChange{
+			HandlerID:      change.HandlerID,
+			RanName:        change.RanName,
+			PreviousStatus: change.PreviousStatus,
+			Status:         change.Status,
+			EnforceReason:  change.Reason,
+			Detail:         change.Detail,
+			InstanceStatus: change.InstanceStatus,
+			ChangedAt:      change.ChangedAt,
+		})
+	}
+	return list
+}
+
+// statusHistoryPair returns the SDL key and value of the status history of the
+// instance with the change added
+func (pm *PolicyManager) statusHistoryPair(policyTypeId int, policyInstanceID string, change StatusChange) ([]interface{}, error) {
+	historyKey := a1StatusHistoryPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + policyInstanceID
+	valmap, err := pm.db.Get(a1MediatorNs, []string{historyKey})
+	if err != nil {
+		a1.Logger.Error("error in retrieving status history err: %v", err)
+		return nil, err
+	}
+	history, err := ParseStatusHistory(valmap[historyKey])
+	if err != nil {
+		a1.Logger.Error("unmarshal error : %v", err)
+		history = nil
+	}
+	history = append(history, change)
+	if len(history) > maxStatusHistory {
+		history = history[len(history)-maxStatusHistory:]
+	}
+	data, err := json.Marshal(history)
+	if err != nil {
+		a1.Logger.Error("marshal error : %v", err)
+		return nil, err
+	}
+	return []interface{}{historyKey, string(data)}, nil
+}
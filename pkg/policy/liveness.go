@@ -0,0 +1,185 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package policy
+
+import (
+	"strconv"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/restapi/operations/a1_mediator"
+)
+
+// LostPolicyTypeHandler is a handler of a policy type that stopped sending heartbeats
+type LostPolicyTypeHandler struct {
+	PolicyTypeId  int
+	HandlerId     string
+	LastHeartbeat string
+}
+
+// RecordHandlerHeartbeat records that a handler is alive for the policy types,
+// or for every policy type it is known to handle when none is given. Policy
+// types that do not exist are skipped.
+func (pm *PolicyManager) RecordHandlerHeartbeat(handlerId string, policyTypeIds []int) error {
+	knownOnly := len(policyTypeIds) == 0
+	if knownOnly {
+		var err error
+		if policyTypeIds, err = pm.GetAllPolicyType(); err != nil {
+			return err
+		}
+	}
+	pm.mutex.Lock()
+	defer pm.mutex.Unlock()
+	now := time.Now().Format("2006-01-02 15:04:05")
+	for _, policyTypeId := range policyTypeIds {
+		if !knownOnly {
+			exists, err := pm.policyTypeExists(policyTypeId)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				a1.Logger.Warning("ignoring heartbeat of %s for unknown policy type %d", handlerId, policyTypeId)
+				continue
+			}
+		}
+		handlers, err := pm.getPolicyTypeHandlers(policyTypeId)
+		if err != nil {
+			return err
+		}
+		handler, known := handlers[handlerId]
+		if !known {
+			if knownOnly {
+				continue
+			}
+			a1.Logger.Info("policy type %d is handled by %s", policyTypeId, handlerId)
+			handler.FirstSeen = now
+		}
+		if handler.Lost {
+			a1.Logger.Info("handler %s of policy type %d is back", handlerId, policyTypeId)
+			handler.Lost = false
+		}
+		handler.LastSeen = now
+		handler.LastHeartbeat = now
+		handlers[handlerId] = handler
+		if err = pm.setPolicyTypeHandlers(policyTypeId, handlers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LostPolicyTypeHandlers returns the handlers whose last heartbeat is older than
+// the timeout and that are not marked lost yet. Handlers that never sent a
+// heartbeat are not tracked.
+func (pm *PolicyManager) LostPolicyTypeHandlers(timeout time.Duration, now time.Time) ([]LostPolicyTypeHandler, error) {
+	policyTypeIds, err := pm.GetAllPolicyType()
+	if err != nil || len(policyTypeIds) == 0 {
+		return nil, err
+	}
+	keys := make([]string, 0, len(policyTypeIds))
+	for _, policyTypeId := range policyTypeIds {
+		keys = append(keys, a1TypeHandlerPrefix+strconv.FormatInt((int64(policyTypeId)), 10))
+	}
+	resp, err := pm.db.Get(a1MediatorNs, keys)
+	if err != nil {
+		a1.Logger.Error("error in retrieving policy type handlers err: %v", err)
+		return nil, err
+	}
+	var lost []LostPolicyTypeHandler
+	for i, policyTypeId := range policyTypeIds {
+		handlers, err := ParsePolicyTypeHandlers(resp[keys[i]])
+		if err != nil {
+			a1.Logger.Error("unmarshal error : %v", err)
+			continue
+		}
+		for _, handler := range PolicyTypeHandlerList(handlers) {
+			if len(handler.LastHeartbeat) == 0 || handler.Lost {
+				continue
+			}
+			lastHeartbeat, err := time.ParseInLocation("2006-01-02 15:04:05", handler.LastHeartbeat, time.Local)
+			if err != nil || now.Sub(lastHeartbeat) <= timeout {
+				continue
+			}
+			lost = append(lost, LostPolicyTypeHandler{PolicyTypeId: policyTypeId, HandlerId: handler.HandlerID, LastHeartbeat: handler.LastHeartbeat})
+		}
+	}
+	return lost, nil
+}
+
+// MarkPolicyTypeHandlerLost marks the handler lost and reports it as no longer
+// enforcing the instances of the policy type it enforced. It returns the
+// instances whose status changed. Nothing is done when the handler sent a
+// heartbeat since it was found lost.
+func (pm *PolicyManager) MarkPolicyTypeHandlerLost(lost LostPolicyTypeHandler, detail string) ([]string, error) {
+	pm.mutex.Lock()
+	handlers, err := pm.getPolicyTypeHandlers(lost.PolicyTypeId)
+	if err != nil {
+		pm.mutex.Unlock()
+		return nil, err
+	}
+	handler, known := handlers[lost.HandlerId]
+	if !known || handler.Lost || handler.LastHeartbeat != lost.LastHeartbeat {
+		pm.mutex.Unlock()
+		return nil, nil
+	}
+	a1.Logger.Warning("handler %s of policy type %d is lost, last heartbeat at %s", lost.HandlerId, lost.PolicyTypeId, lost.LastHeartbeat)
+	handler.Lost = true
+	handlers[lost.HandlerId] = handler
+	err = pm.setPolicyTypeHandlers(lost.PolicyTypeId, handlers)
+	pm.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	instances, err := pm.GetAllPolicyInstance(lost.PolicyTypeId)
+	if err != nil {
+		if IsPolicyInstanceNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var changedInstances []string
+	for _, instance := range instances {
+		policyInstanceID := string(instance)
+		statuses, err := pm.GetPolicyHandlerStatus(lost.PolicyTypeId, policyInstanceID)
+		if err != nil {
+			continue
+		}
+		changed := false
+		for key, status := range statuses {
+			if handlerIdOf(key, status) != lost.HandlerId || status.Status != handlerStatusOK {
+				continue
+			}
+			instanceChanged, err := pm.SetPolicyInstanceStatus(lost.PolicyTypeId, policyInstanceID, lost.HandlerId, status.RanName, handlerStatusError,
+				a1_mediator.A1ControllerGetPolicyInstanceStatusOKBodyEnforceReasonOTHERREASON, detail)
+			if err != nil {
+				a1.Logger.Error("failed to set the status of %d.%s for lost handler %s : %v", lost.PolicyTypeId, policyInstanceID, lost.HandlerId, err)
+				continue
+			}
+			changed = changed || instanceChanged
+		}
+		if changed {
+			changedInstances = append(changedInstances, policyInstanceID)
+		}
+	}
+	return changedInstances, nil
+}
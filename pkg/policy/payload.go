@@ -0,0 +1,107 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package policy
+
+import (
+	"strings"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+)
+
+// StoreMessagePayload keeps the payload of an RMR message in SDL until ttl has
+// passed and returns the reference sent to the xApps in its place. The expiry is
+// stored next to the payload, so that SweepMessagePayloads can remove the
+// payloads left by a restart.
+func (pm *PolicyManager) StoreMessagePayload(id string, payload []byte, ttl time.Duration) (*MessagePayloadRef, error) {
+	ref := &MessagePayloadRef{
+		Namespace: a1MediatorNs,
+		Key:       a1MessagePayloadPrefix + id,
+		Size:      len(payload),
+		ExpiresAt: time.Now().Add(ttl).UTC().Format(time.RFC3339),
+	}
+	if err := pm.db.Set(a1MediatorNs, ref.Key, string(payload), a1MessagePayloadExpiryPrefix+id, ref.ExpiresAt); err != nil {
+		a1.Logger.Error("error in storing message payload err: %v", err)
+		return nil, err
+	}
+	return ref, nil
+}
+
+// RemoveMessagePayload removes a payload stored by StoreMessagePayload
+func (pm *PolicyManager) RemoveMessagePayload(ref *MessagePayloadRef) error {
+	id := strings.TrimPrefix(ref.Key, a1MessagePayloadPrefix)
+	if err := pm.db.Remove(a1MediatorNs, []string{ref.Key, a1MessagePayloadExpiryPrefix + id}); err != nil {
+		a1.Logger.Error("error in deleting message payload err: %v", err)
+		return err
+	}
+	return nil
+}
+
+// SweepMessagePayloads removes the stored payloads that expired before now, or
+// whose expiry or payload is missing, and returns the references still valid
+func (pm *PolicyManager) SweepMessagePayloads(now time.Time) ([]*MessagePayloadRef, error) {
+	keys, err := pm.db.GetAll(a1MediatorNs)
+	if err != nil {
+		a1.Logger.Error("error in retrieving keys err: %v", err)
+		return nil, err
+	}
+	ids := map[string]bool{}
+	var payloadKeys []string
+	for _, key := range keys {
+		if strings.HasPrefix(key, a1MessagePayloadPrefix) {
+			ids[strings.TrimPrefix(key, a1MessagePayloadPrefix)] = true
+			payloadKeys = append(payloadKeys, key)
+		} else if strings.HasPrefix(key, a1MessagePayloadExpiryPrefix) {
+			ids[strings.TrimPrefix(key, a1MessagePayloadExpiryPrefix)] = true
+			payloadKeys = append(payloadKeys, key)
+		}
+	}
+	if len(payloadKeys) == 0 {
+		return nil, nil
+	}
+	valmap, err := pm.db.Get(a1MediatorNs, payloadKeys)
+	if err != nil {
+		a1.Logger.Error("error in retrieving message payloads err: %v", err)
+		return nil, err
+	}
+	var refs []*MessagePayloadRef
+	var expiredKeys []string
+	for id := range ids {
+		payloadKey, expiryKey := a1MessagePayloadPrefix+id, a1MessagePayloadExpiryPrefix+id
+		payload, hasPayload := valmap[payloadKey].(string)
+		expiresAt, _ := valmap[expiryKey].(string)
+		expiry, err := time.Parse(time.RFC3339, expiresAt)
+		if !hasPayload || err != nil || !expiry.After(now) {
+			expiredKeys = append(expiredKeys, payloadKey, expiryKey)
+			continue
+		}
+		refs = append(refs, &MessagePayloadRef{Namespace: a1MediatorNs, Key: payloadKey, Size: len(payload), ExpiresAt: expiresAt})
+	}
+	if len(expiredKeys) > 0 {
+		a1.Logger.Debug("removing %d expired message payloads", len(expiredKeys)/2)
+		if err := pm.db.Remove(a1MediatorNs, expiredKeys); err != nil {
+			a1.Logger.Error("error in deleting message payload err: %v", err)
+			return nil, err
+		}
+	}
+	return refs, nil
+}
@@ -25,14 +25,15 @@
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
 	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/models"
 	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/notification"
 	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/restapi/operations/a1_mediator"
-	"gerrit.o-ran-sc.org/r/ric-plt/sdlgo"
 )
 
 var policyTypeNotFoundError = errors.New("Policy Type Not Found")
@@ -40,13 +41,34 @@
 
 const (
 	a1HandlerPrefix                 = "a1.policy_handler."
+	a1HandlerStatusPrefix           = "a1.policy_handler_status."
+	a1AckPrefix                     = "a1.policy_ack."
+	a1InstanceMetadataPrefix        = "a1.policy_inst_metadata."
 	a1PolicyPrefix                  = "a1.policy_type."
 	a1MediatorNs                    = "A1m_ns"
 	a1InstancePrefix                = "a1.policy_instance."
 	a1NotificationDestinationPrefix = "a1.policy_notification_destination."
+	a1TransactionPrefix             = "a1.policy_transaction."
+	a1TypeHandlerPrefix             = "a1.policy_type_handler."
+	a1MessagePayloadPrefix          = "a1.rmr_payload."
+	a1MessagePayloadExpiryPrefix    = "a1.rmr_payload_expiry."
+	a1StatusHistoryPrefix           = "a1.policy_status_history."
+	a1FeedbackPrefix                = "a1.policy_feedback."
+	a1SchedulePrefix                = "a1.policy_schedule."
+	handlerStatusOK                 = "OK"
+	handlerStatusError              = "ERROR"
+	handlerStatusDeleted            = "DELETED"
 )
 
-func NewPolicyManager(sdl *sdlgo.SyncStorage) *PolicyManager {
+const (
+	AggregateStatusAllOK     = a1_mediator.A1ControllerGetPolicyInstanceStatusOKBodyAggregateStatusALLOK
+	AggregateStatusPartial   = a1_mediator.A1ControllerGetPolicyInstanceStatusOKBodyAggregateStatusPARTIAL
+	AggregateStatusFailed    = a1_mediator.A1ControllerGetPolicyInstanceStatusOKBodyAggregateStatusFAILED
+	EnforceStatusEnforced    = a1_mediator.A1ControllerGetPolicyInstanceStatusOKBodyEnforceStatusENFORCED
+	EnforceStatusNotEnforced = a1_mediator.A1ControllerGetPolicyInstanceStatusOKBodyEnforceStatusNOTENFORCED
+)
+
+func NewPolicyManager(sdl iSdl) *PolicyManager {
 	return createPolicyManager(sdl)
 }
 
@@ -56,15 +78,340 @@ func createPolicyManager(sdlInst iSdl) *PolicyManager {
 	}
 	return pm
 }
-func (pm *PolicyManager) SetPolicyInstanceStatus(policyTypeId int, policyInstanceID string, status string) error {
-	a1.Logger.Debug("In SetPolicyInstanceStatus message recieved for %d and %s", policyTypeId, policyInstanceID)
+
+// SetPolicyInstanceStatus records the status reported by one handler of the
+// instance, for the RAN node it reported it for if any, and updates the instance
+// status, which is OK as long as at least one handler enforces the policy, and
+// marks the request as acknowledged. The enforce reason and detail are only kept
+// for handlers that do not enforce the policy. A status differing from the one
+// the handler reported before is added to the status history of the instance.
+// It tells whether the notified status of the instance changed, that is its
+// enforce status, aggregated status, enforce reason or detail.
+// While the instance is being deleted only DELETED is accepted, and the instance
+// is removed once every handler has reported it.
+func (pm *PolicyManager) SetPolicyInstanceStatus(policyTypeId int, policyInstanceID string, handlerId string, ranName string, status string, reason string, detail string) (bool, error) {
+	a1.Logger.Debug("In SetPolicyInstanceStatus message recieved for %d and %s from %s ran %s", policyTypeId, policyInstanceID, handlerId, ranName)
+	pm.mutex.Lock()
+	changed, deletedNotificationDestination, err := pm.setPolicyInstanceStatus(policyTypeId, policyInstanceID, handlerId, ranName, status, reason, detail)
+	// the metadata is also written by the REST handlers, which do not hold the lock
+	for attempt := 1; IsMetadataConflict(err) && attempt < maxMetadataUpdateAttempts; attempt++ {
+		changed, deletedNotificationDestination, err = pm.setPolicyInstanceStatus(policyTypeId, policyInstanceID, handlerId, ranName, status, reason, detail)
+	}
+	pm.mutex.Unlock()
+	// the notification destination is only called once the lock is released
+	if len(deletedNotificationDestination) > 0 {
+		if err := SendPolicyDeletedNotification(deletedNotificationDestination); err != nil {
+			a1.Logger.Error("failed to send policy deleted notification : %v", err)
+		}
+	}
+	return changed, err
+}
+
+// setPolicyInstanceStatus does the work of SetPolicyInstanceStatus under the lock.
+// It returns the notification destination to tell when the instance got removed.
+func (pm *PolicyManager) setPolicyInstanceStatus(policyTypeId int, policyInstanceID string, handlerId string, ranName string, status string, reason string, detail string) (bool, string, error) {
+	instanceMetadataKey := a1InstanceMetadataPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + policyInstanceID
+	metadataMap, err := pm.db.Get(a1MediatorNs, []string{instanceMetadataKey})
+	if err != nil {
+		a1.Logger.Error("error1 :%+v", err)
+		return false, "", err
+	}
+	metadata, hasMetadata := metadataMap[instanceMetadataKey].(string)
+	currentState := InstanceMetadataState(metadata)
+	if currentState == InstanceStateDeleting {
+		if status != handlerStatusDeleted {
+			a1.Logger.Error("policy instance %d.%s is %s, ignoring status %s from %s", policyTypeId, policyInstanceID, currentState, status, handlerId)
+			return false, "", invalidStateTransitionError
+		}
+		deletedNotificationDestination, err := pm.acknowledgePolicyInstanceDelete(policyTypeId, policyInstanceID, handlerId, ranName, metadata)
+		return false, deletedNotificationDestination, err
+	}
+
+	handlers, err := pm.GetPolicyHandlerStatus(policyTypeId, policyInstanceID)
+	if err != nil {
+		return false, "", err
+	}
+	before := PolicyStatusBody(HandlersEnforce(handlers), "", "", handlers)
+	handlerStatus := HandlerStatus{Status: status, UpdatedAt: time.Now().Format("2006-01-02 15:04:05"), RanName: ranName}
+	if status != handlerStatusOK {
+		handlerStatus.Reason = normalizeEnforceReason(reason)
+		handlerStatus.Detail = detail
+	}
+	handlerKey := HandlerStatusKey(handlerId, ranName)
+	previous, reported := handlers[handlerKey]
+	handlers[handlerKey] = handlerStatus
+	data, err := json.Marshal(handlers)
+	if err != nil {
+		a1.Logger.Error("marshal error : %v", err)
+		return false, "", err
+	}
+	instanceStatus := status
+	instanceState := InstanceStateNotEnforced
+	if HandlersEnforce(handlers) {
+		instanceStatus = handlerStatusOK
+		instanceState = InstanceStateEnforced
+	}
 	instancehandlerKey := a1HandlerPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + policyInstanceID
-	err := pm.db.Set(a1MediatorNs, instancehandlerKey, status)
+	handlerStatusKey := a1HandlerStatusPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + policyInstanceID
+	ackKey := a1AckPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + policyInstanceID
+	pairs := []interface{}{instancehandlerKey, instanceStatus, handlerStatusKey, string(data), ackKey, a1_mediator.A1ControllerGetPolicyInstanceStatusOKBodyAckStatusACKNOWLEDGED}
+	if hasMetadata {
+		if err = ValidateStateTransition(currentState, instanceState); err != nil {
+			a1.Logger.Error("policy instance %d.%s is %s, ignoring status %s from %s", policyTypeId, policyInstanceID, currentState, status, handlerId)
+			return false, "", err
+		}
+		var updatedMetadata string
+		updatedMetadata, err = SetInstanceMetadataState(metadata, instanceState)
+		if err != nil {
+			a1.Logger.Error("unmarshal error : %v", err)
+			return false, "", err
+		}
+		// the instance may have been deleted since its metadata was read
+		var stored bool
+		stored, err = pm.db.SetIf(a1MediatorNs, instanceMetadataKey, metadata, updatedMetadata)
+		if err != nil {
+			a1.Logger.Error("error1 :%+v", err)
+			return false, "", err
+		}
+		if !stored {
+			a1.Logger.Debug("policy instance %d.%s metadata changed meanwhile", policyTypeId, policyInstanceID)
+			return false, "", metadataConflictError
+		}
+	}
+	if !reported || previous.Status != handlerStatus.Status || previous.Reason != handlerStatus.Reason {
+		change := StatusChange{
+			HandlerID:      handlerId,
+			RanName:        ranName,
+			PreviousStatus: previous.Status,
+			Status:         status,
+			Reason:         handlerStatus.Reason,
+			Detail:         handlerStatus.Detail,
+			InstanceStatus: instanceStatus,
+			ChangedAt:      handlerStatus.UpdatedAt,
+		}
+		historyPair, err := pm.statusHistoryPair(policyTypeId, policyInstanceID, change)
+		if err != nil {
+			return false, "", err
+		}
+		pairs = append(pairs, historyPair...)
+	}
+
+	err = pm.db.Set(a1MediatorNs, pairs...)
+	if err != nil {
+		a1.Logger.Error("error1 :%+v", err)
+		return false, "", err
+	}
+	after := PolicyStatusBody(HandlersEnforce(handlers), "", "", handlers)
+	return statusChanged(before, after), "", nil
+}
+
+// acknowledgePolicyInstanceDelete records that a handler removed the instance and
+// removes the instance once all its handlers did, returning the notification
+// destination to tell about it
+func (pm *PolicyManager) acknowledgePolicyInstanceDelete(policyTypeId int, policyInstanceID string, handlerId string, ranName string, metadata string) (string, error) {
+	handlers, err := pm.GetPolicyHandlerStatus(policyTypeId, policyInstanceID)
+	if err != nil {
+		return "", err
+	}
+	handlers[HandlerStatusKey(handlerId, ranName)] = HandlerStatus{Status: handlerStatusDeleted, UpdatedAt: time.Now().Format("2006-01-02 15:04:05"), RanName: ranName}
+	for id, handler := range handlers {
+		if handler.Status != handlerStatusDeleted {
+			a1.Logger.Debug("policy instance %d.%s still waiting for %s to delete it", policyTypeId, policyInstanceID, id)
+			data, err := json.Marshal(handlers)
+			if err != nil {
+				a1.Logger.Error("marshal error : %v", err)
+				return "", err
+			}
+			handlerStatusKey := a1HandlerStatusPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + policyInstanceID
+			return "", pm.db.Set(a1MediatorNs, handlerStatusKey, string(data))
+		}
+	}
+	return pm.removePolicyInstance(policyTypeId, policyInstanceID, metadata)
+}
+
+// removePolicyInstance removes the data of a deleted instance and keeps its
+// deletion metadata. It returns the notification destination of the instance.
+func (pm *PolicyManager) removePolicyInstance(policyTypeId int, policyInstanceID string, metadata string) (string, error) {
+	suffix := strconv.FormatInt((int64(policyTypeId)), 10) + "." + policyInstanceID
+	notificationDestinationkey := a1NotificationDestinationPrefix + suffix
+	data, err := pm.db.Get(a1MediatorNs, []string{notificationDestinationkey})
+	if err != nil {
+		a1.Logger.Error("error1 :%+v", err)
+		return "", err
+	}
+	deletedMetadata, err := DeletedInstanceMetadata(metadata, time.Now())
 	if err != nil {
+		a1.Logger.Error("unmarshal error : %v", err)
+		return "", err
+	}
+	if err = pm.db.Remove(a1MediatorNs, InstanceDataKeys(policyTypeId, policyInstanceID)); err != nil {
+		a1.Logger.Error("error in deleting policy instance err: %v", err)
+		return "", err
+	}
+	if err = pm.db.Set(a1MediatorNs, a1InstanceMetadataPrefix+suffix, deletedMetadata); err != nil {
 		a1.Logger.Error("error1 :%+v", err)
+		return "", err
+	}
+	a1.Logger.Debug("policy instance %d.%s deleted by all its handlers", policyTypeId, policyInstanceID)
+	notificationDestination, _ := data[notificationDestinationkey].(string)
+	return notificationDestination, nil
+}
+
+// InstanceDataKeys returns every SDL key holding data of the instance, which are
+// all removed when the instance is deleted. Only its metadata is kept, as the
+// record of the deletion.
+func InstanceDataKeys(policyTypeId int, policyInstanceID string) []string {
+	suffix := strconv.FormatInt((int64(policyTypeId)), 10) + "." + policyInstanceID
+	return []string{
+		a1InstancePrefix + suffix,
+		a1NotificationDestinationPrefix + suffix,
+		a1HandlerPrefix + suffix,
+		a1HandlerStatusPrefix + suffix,
+		a1AckPrefix + suffix,
+		a1TransactionPrefix + suffix,
+		a1StatusHistoryPrefix + suffix,
+		a1FeedbackPrefix + suffix,
+		a1SchedulePrefix + suffix,
+	}
+}
+
+// SendPolicyDeletedNotification tells the notification destination of an instance
+// that the instance has been removed from the xApps
+func SendPolicyDeletedNotification(notificationDestination string) error {
+	policyInstanceStatus := PolicyStatusBody(false, InstanceStateDeleted, "", nil)
+	jsonbody, err := json.Marshal(policyInstanceStatus)
+	if err != nil {
 		return err
 	}
-	return nil
+	return notification.SendNotification(notificationDestination, string(jsonbody))
+}
+
+// GetPolicyHandlerStatus returns the status reported by each handler of the instance
+func (pm *PolicyManager) GetPolicyHandlerStatus(policyTypeId int, policyInstanceID string) (map[string]HandlerStatus, error) {
+	handlerStatusKey := a1HandlerStatusPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + policyInstanceID
+	keys := []string{handlerStatusKey}
+	resp, err := pm.db.Get(a1MediatorNs, keys)
+	if err != nil {
+		a1.Logger.Error("error1 :%+v", err)
+		return nil, err
+	}
+	return ParseHandlerStatus(resp[handlerStatusKey])
+}
+
+// ParseHandlerStatus decodes the per handler status record stored in SDL
+func ParseHandlerStatus(data interface{}) (map[string]HandlerStatus, error) {
+	handlers := map[string]HandlerStatus{}
+	str, ok := data.(string)
+	if !ok {
+		return handlers, nil
+	}
+	if err := json.Unmarshal([]byte(str), &handlers); err != nil {
+		a1.Logger.Error("unmarshal error : %v", err)
+		return nil, err
+	}
+	return handlers, nil
+}
+
+// AggregateHandlerStatus summarizes the handler statuses of an instance as
+// ALL_OK, PARTIAL or FAILED. It is empty while no handler has replied.
+func AggregateHandlerStatus(handlers map[string]HandlerStatus) string {
+	if len(handlers) == 0 {
+		return ""
+	}
+	enforced := 0
+	for _, handler := range handlers {
+		if handler.Status == handlerStatusOK {
+			enforced++
+		}
+	}
+	switch enforced {
+	case len(handlers):
+		return AggregateStatusAllOK
+	case 0:
+		return AggregateStatusFailed
+	}
+	return AggregateStatusPartial
+}
+
+// HandlersEnforce tells whether at least one handler enforces the instance
+func HandlersEnforce(handlers map[string]HandlerStatus) bool {
+	aggregateStatus := AggregateHandlerStatus(handlers)
+	return aggregateStatus == AggregateStatusAllOK || aggregateStatus == AggregateStatusPartial
+}
+
+// PolicyStatusBody builds the status of an instance from its lifecycle state,
+// acknowledgement and handler statuses. Every notification sent to the
+// notification destination carries this body. The enforce reason and detail are
+// only given for an instance that is not enforced and not deleted.
+func PolicyStatusBody(enforced bool, instanceState string, ackStatus string, handlers map[string]HandlerStatus) a1_mediator.A1ControllerGetPolicyInstanceStatusOKBody {
+	policyInstanceStatus := a1_mediator.A1ControllerGetPolicyInstanceStatusOKBody{
+		EnforceStatus:   EnforceStatusEnforced,
+		InstanceState:   instanceState,
+		AckStatus:       ackStatus,
+		AggregateStatus: AggregateHandlerStatus(handlers),
+		Handlers:        HandlerStatusList(handlers),
+	}
+	if !enforced {
+		policyInstanceStatus.EnforceStatus = EnforceStatusNotEnforced
+		if instanceState != InstanceStateDeleted {
+			policyInstanceStatus.EnforceReason, policyInstanceStatus.EnforceDetail = EnforceReason(handlers)
+		}
+	}
+	return policyInstanceStatus
+}
+
+// statusChanged tells whether the notified part of the status differs
+func statusChanged(before, after a1_mediator.A1ControllerGetPolicyInstanceStatusOKBody) bool {
+	return before.EnforceStatus != after.EnforceStatus ||
+		before.AggregateStatus != after.AggregateStatus ||
+		before.EnforceReason != after.EnforceReason ||
+		before.EnforceDetail != after.EnforceDetail
+}
+
+// normalizeEnforceReason maps the reason sent by an xApp to one of the A1AP
+// enforce reasons, anything unknown being reported as OTHER_REASON
+func normalizeEnforceReason(reason string) string {
+	switch reason {
+	case a1_mediator.A1ControllerGetPolicyInstanceStatusOKBodyEnforceReasonSCOPENOTAPPLICABLE,
+		a1_mediator.A1ControllerGetPolicyInstanceStatusOKBodyEnforceReasonSTATEMENTNOTAPPLICABLE:
+		return reason
+	}
+	return a1_mediator.A1ControllerGetPolicyInstanceStatusOKBodyEnforceReasonOTHERREASON
+}
+
+// EnforceReason returns the enforce reason and detail of the first handler, by
+// handler id, that does not enforce the instance. OTHER_REASON is returned when
+// no handler gave a reason.
+func EnforceReason(handlers map[string]HandlerStatus) (string, string) {
+	for _, handler := range HandlerStatusList(handlers) {
+		if handler.Status != handlerStatusOK && len(handler.EnforceReason) > 0 {
+			return handler.EnforceReason, handler.Detail
+		}
+	}
+	return a1_mediator.A1ControllerGetPolicyInstanceStatusOKBodyEnforceReasonOTHERREASON, ""
+}
+
+// HandlerStatusList converts the handler statuses to the REST model, ordered by
+// handler id and RAN node
+func HandlerStatusList(handlers map[string]HandlerStatus) []*models.PolicyHandlerStatus {
+	handlerKeys := make([]string, 0, len(handlers))
+	for handlerKey := range handlers {
+		handlerKeys = append(handlerKeys, handlerKey)
+	}
+	sort.Strings(handlerKeys)
+	handlerStatuses := make([]*models.PolicyHandlerStatus, 0, len(handlers))
+	for _, handlerKey := range handlerKeys {
+		handler := handlers[handlerKey]
+		handlerStatuses = append(handlerStatuses, &models.PolicyHandlerStatus{
+			HandlerID:     handlerIdOf(handlerKey, handler),
+			RanName:       handler.RanName,
+			Status:        handler.Status,
+			EnforceReason: handler.Reason,
+			Detail:        handler.Detail,
+			UpdatedAt:     handler.UpdatedAt,
+		})
+	}
+	return handlerStatuses
 }
 
 func (pm *PolicyManager) GetPolicyInstanceStatus(policyTypeId int, policyInstanceID string) (bool, error) {
@@ -105,16 +452,25 @@ func (pm *PolicyManager) SendPolicyStatusNotification(policyTypeId int, policyIn
 		return errors.New("failed to process notificationDestination URL")
 	}
 
-	policyInstanceStatus := a1_mediator.A1ControllerGetPolicyInstanceStatusOKBody{EnforceStatus: "ENFORCED"}
 	enforced, err := pm.GetPolicyInstanceStatus(policyTypeId, policyInstanceID)
 	if err != nil {
 		return err
 	}
-
-	if !enforced {
-		policyInstanceStatus.EnforceStatus = "NOT ENFORCED"
-		policyInstanceStatus.EnforceReason = "OTHER_REASON"
+	handlers, err := pm.GetPolicyHandlerStatus(policyTypeId, policyInstanceID)
+	if err != nil {
+		return err
+	}
+	suffix := strconv.FormatInt((int64(policyTypeId)), 10) + "." + policyInstanceID
+	instanceMetadataKey := a1InstanceMetadataPrefix + suffix
+	ackKey := a1AckPrefix + suffix
+	resp, err := pm.db.Get(a1MediatorNs, []string{instanceMetadataKey, ackKey})
+	if err != nil {
+		a1.Logger.Error("error1 :%+v", err)
+		return err
 	}
+	metadata, _ := resp[instanceMetadataKey].(string)
+	ackStatus, _ := resp[ackKey].(string)
+	policyInstanceStatus := PolicyStatusBody(enforced, InstanceMetadataState(metadata), ackStatus, handlers)
 
 	jsonbody, err := json.Marshal(policyInstanceStatus)
 	if err != nil {
@@ -127,6 +483,33 @@ func (pm *PolicyManager) SendPolicyStatusNotification(policyTypeId int, policyIn
 	return nil
 }
 
+// IsPolicyInstanceNotFound tells whether the error reports a missing policy instance
+func IsPolicyInstanceNotFound(err error) bool {
+	return err == policyInstanceNotFoundError
+}
+
+// GetAllPolicyType returns the ids of the registered policy types
+func (pm *PolicyManager) GetAllPolicyType() ([]int, error) {
+	keys, err := pm.db.GetAll(a1MediatorNs)
+	if err != nil {
+		a1.Logger.Error("error in retrieving policy types. err: %v", err)
+		return nil, err
+	}
+	var policyTypeIds []int
+	for _, key := range keys {
+		if !strings.HasPrefix(key, a1PolicyPrefix) {
+			continue
+		}
+		policyTypeId, err := strconv.Atoi(strings.TrimPrefix(key, a1PolicyPrefix))
+		if err != nil {
+			continue
+		}
+		policyTypeIds = append(policyTypeIds, policyTypeId)
+	}
+	sort.Ints(policyTypeIds)
+	return policyTypeIds, nil
+}
+
 func (im *PolicyManager) GetAllPolicyInstance(policyTypeId int) ([]models.PolicyInstanceID, error) {
 	a1.Logger.Debug("GetAllPolicyInstance")
 	var policyTypeInstances = []models.PolicyInstanceID{}
@@ -22,10 +22,14 @@
 package policy
 
 import (
+	"encoding/json"
         "errors"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strconv"
 	"testing"
+	"time"
         "fmt"
 	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
         "gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/models"
@@ -33,11 +37,6 @@
 	"github.com/stretchr/testify/mock"
 )
 
-const (
-        a1InstanceMetadataPrefix        = "a1.policy_inst_metadata."
-)
-
-
 type SdlMock struct {
 	mock.Mock
 }
@@ -59,10 +58,21 @@ func TestSetPolicyInstance(t *testing.T) {
 	var status string
 	status = "OK"
 	instancehandlerKey := a1HandlerPrefix + strconv.FormatInt(20001, 10) + "." + policyInstanceID
-	instancearr := []interface{}{instancehandlerKey, status}
-	sdlInst.On("Set", "A1m_ns", instancearr).Return(nil)
-	errresp := pm.SetPolicyInstanceStatus(policyTypeId, policyInstanceID, status)
+	handlerStatusKey := a1HandlerStatusPrefix + strconv.FormatInt(20001, 10) + "." + policyInstanceID
+	sdlInst.On("Get", "A1m_ns", []string{handlerStatusKey}).Return(map[string]interface{}{}, nil).Once()
+	instanceMetadataKey := a1InstanceMetadataPrefix + strconv.FormatInt(20001, 10) + "." + policyInstanceID
+	sdlInst.On("Get", "A1m_ns", []string{instanceMetadataKey}).Return(map[string]interface{}{}, nil).Once()
+	statusHistoryKey := a1StatusHistoryPrefix + strconv.FormatInt(20001, 10) + "." + policyInstanceID
+	sdlInst.On("Get", "A1m_ns", []string{statusHistoryKey}).Return(map[string]interface{}{}, nil).Once()
+	sdlInst.On("SetIf", "A1m_ns", instanceMetadataKey, mock.Anything, mock.MatchedBy(func(metadata string) bool {
+		return InstanceMetadataState(metadata) == InstanceStateEnforced
+	})).Return(true, nil).Once()
+	sdlInst.On("Set", "A1m_ns", mock.MatchedBy(func(pairs []interface{}) bool {
+		return len(pairs) == 8 && pairs[0] == instancehandlerKey && pairs[1] == status && pairs[2] == handlerStatusKey && pairs[6] == statusHistoryKey
+	})).Return(nil).Once()
+	changed, errresp := pm.SetPolicyInstanceStatus(policyTypeId, policyInstanceID, "xapp1", "", status, "", "")
 	assert.NoError(t, errresp)
+	assert.True(t, changed)
 	sdlInst.AssertExpectations(t)
 }
 
@@ -72,8 +82,14 @@ func TestSetPolicyInstanceFail(t *testing.T) {
         policyInstanceID := ""
         var status string
         status = "NOK"
+        handlerStatusKey := a1HandlerStatusPrefix + strconv.FormatInt(0, 10) + "." + policyInstanceID
+        sdlInst.On("Get", "A1m_ns", []string{handlerStatusKey}).Return(map[string]interface{}{}, nil).Once()
+        instanceMetadataKey := a1InstanceMetadataPrefix + strconv.FormatInt(0, 10) + "." + policyInstanceID
+        sdlInst.On("Get", "A1m_ns", []string{instanceMetadataKey}).Return(map[string]interface{}{}, nil).Once()
+        statusHistoryKey := a1StatusHistoryPrefix + strconv.FormatInt(0, 10) + "." + policyInstanceID
+        sdlInst.On("Get", "A1m_ns", []string{statusHistoryKey}).Return(map[string]interface{}{}, nil).Once()
         sdlInst.On("Set", "A1m_ns", mock.Anything).Return(errors.New("Some Error"))
-        errresp := pm.SetPolicyInstanceStatus(policyTypeId, policyInstanceID, status)
+        _, errresp := pm.SetPolicyInstanceStatus(policyTypeId, policyInstanceID, "xapp1", "", status, "", "")
         a1.Logger.Debug("err from set test  : %+v", errresp)
         assert.Error(t, errresp)
         sdlInst.AssertExpectations(t)
@@ -248,6 +264,9 @@ func TestSendPolicyStatusNotification(t *testing.T) {
         instancearr := []interface{}{instancekey, "OK"}
         //Setup Expectations
         sdlInst.On("Get", "A1m_ns", instancekeys[:]).Return(instancearr, nil).Once()
+        handlerStatusKey := a1HandlerStatusPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + policyInstanceID
+        sdlInst.On("Get", "A1m_ns", []string{handlerStatusKey}).Return(map[string]interface{}{}, nil).Once()
+	sdlInst.On("Get", "A1m_ns", []string{a1InstanceMetadataPrefix + "20001.123456", a1AckPrefix + "20001.123456"}).Return(map[string]interface{}{}, nil).Once()
         err := pm.SendPolicyStatusNotification(policyTypeId,policyInstanceID,notificationDestinationkey,status)
         assert.Nil(t, err)
         sdlInst.AssertExpectations(t)
@@ -295,6 +314,410 @@ func TestSendPolicyStatusNotificationFail2(t *testing.T) {
         sdlInst.AssertExpectations(t)
 }
 
+func TestAggregateHandlerStatus(t *testing.T) {
+	assert.Equal(t, "", AggregateHandlerStatus(map[string]HandlerStatus{}))
+	assert.Equal(t, AggregateStatusAllOK, AggregateHandlerStatus(map[string]HandlerStatus{"xapp1": {Status: "OK"}, "xapp2": {Status: "OK"}}))
+	assert.Equal(t, AggregateStatusPartial, AggregateHandlerStatus(map[string]HandlerStatus{"xapp1": {Status: "OK"}, "xapp2": {Status: "ERROR"}}))
+	assert.Equal(t, AggregateStatusFailed, AggregateHandlerStatus(map[string]HandlerStatus{"xapp1": {Status: "ERROR"}}))
+}
+
+func TestEnforceReason(t *testing.T) {
+	handlers := map[string]HandlerStatus{
+		"xapp1": {Status: "OK"},
+		"xapp2": {Status: "ERROR", Reason: normalizeEnforceReason("SCOPE_NOT_APPLICABLE"), Detail: "cell not served"},
+		"xapp3": {Status: "ERROR", Reason: normalizeEnforceReason("UNKNOWN")},
+	}
+	reason, detail := EnforceReason(handlers)
+	assert.Equal(t, "SCOPE_NOT_APPLICABLE", reason)
+	assert.Equal(t, "cell not served", detail)
+	assert.Equal(t, "OTHER_REASON", handlers["xapp3"].Reason)
+	reason, detail = EnforceReason(map[string]HandlerStatus{})
+	assert.Equal(t, "OTHER_REASON", reason)
+	assert.Equal(t, "", detail)
+}
+
+func TestValidateStateTransition(t *testing.T) {
+	assert.NoError(t, ValidateStateTransition("", InstanceStateEnforced))
+	assert.NoError(t, ValidateStateTransition(InstanceStatePending, InstanceStateEnforced))
+	assert.NoError(t, ValidateStateTransition(InstanceStateDeleted, InstanceStatePending))
+	assert.True(t, IsInvalidStateTransition(ValidateStateTransition(InstanceStateDeleting, InstanceStatePending)))
+	assert.True(t, IsInvalidStateTransition(ValidateStateTransition(InstanceStateDeleted, InstanceStateEnforced)))
+}
+
+func TestInstanceMetadataState(t *testing.T) {
+	metadata, err := SetInstanceMetadataState(`[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False"}]`, InstanceStatePending)
+	assert.NoError(t, err)
+	assert.Equal(t, InstanceStatePending, InstanceMetadataState(metadata))
+	metadata, err = SetInstanceMetadataState(`{"created_at":"2022-11-02 10:30:20","has_been_deleted":"True"}`, InstanceStateDeleted)
+	assert.NoError(t, err)
+	assert.Equal(t, InstanceStateDeleted, InstanceMetadataState(metadata))
+	_, err = SetInstanceMetadataState("testval", InstanceStatePending)
+	assert.Error(t, err)
+}
+
+func TestSetPolicyInstanceStatusDeleting(t *testing.T) {
+	instanceMetadataKey := a1InstanceMetadataPrefix + "20001.654321"
+	handlerStatusKey := a1HandlerStatusPrefix + "20001.654321"
+	notificationDestinationKey := a1NotificationDestinationPrefix + "20001.654321"
+	sdlInst.On("Get", "A1m_ns", []string{instanceMetadataKey}).Return(map[string]interface{}{}, nil).Twice()
+	_, err := pm.SetPolicyInstanceStatus(20001, "654321", "xapp1", "", "OK", "", "")
+	assert.True(t, IsInvalidStateTransition(err))
+
+	sdlInst.On("Get", "A1m_ns", []string{handlerStatusKey}).Return(map[string]interface{}{}, nil).Once()
+	sdlInst.On("Get", "A1m_ns", []string{notificationDestinationKey}).Return(map[string]interface{}{}, nil).Once()
+	keys := InstanceDataKeys(20001, "654321")
+	sdlInst.On("Remove", "A1m_ns", keys).Return(nil).Once()
+	sdlInst.On("Set", "A1m_ns", mock.MatchedBy(func(pairs []interface{}) bool {
+		return len(pairs) == 2 && pairs[0] == instanceMetadataKey && InstanceMetadataState(pairs[1].(string)) == InstanceStateDeleted
+	})).Return(nil).Once()
+	pm.SetPolicyInstanceStatus(20001, "654321", "xapp1", "", "DELETED", "", "")
+	sdlInst.AssertCalled(t, "Remove", "A1m_ns", keys)
+}
+
+func TestSetPolicyInstanceStatusDeletedNotification(t *testing.T) {
+	store := memSdl{}
+	deletedPm := createPolicyManager(store)
+	locked := make(chan bool, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if deletedPm.mutex.TryLock() {
+			deletedPm.mutex.Unlock()
+			locked <- false
+		} else {
+			locked <- true
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	store[a1InstanceMetadataPrefix+"20001.654328"] = `[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"DELETING"}]`
+	store[a1InstancePrefix+"20001.654328"] = `{"enforce":true}`
+	store[a1NotificationDestinationPrefix+"20001.654328"] = server.URL
+	store[a1TransactionPrefix+"20001.654328"] = `{"transaction_id":"0123456789abcdef","revision":1,"operation":"DELETE"}`
+
+	_, err := deletedPm.SetPolicyInstanceStatus(20001, "654328", "xapp1", "", "DELETED", "", "")
+
+	assert.Nil(t, err)
+	assert.Equal(t, InstanceStateDeleted, InstanceMetadataState(store[a1InstanceMetadataPrefix+"20001.654328"].(string)))
+	assert.NotContains(t, store, a1InstancePrefix+"20001.654328")
+	assert.NotContains(t, store, a1NotificationDestinationPrefix+"20001.654328")
+	assert.NotContains(t, store, a1TransactionPrefix+"20001.654328")
+	select {
+	case heldLock := <-locked:
+		assert.False(t, heldLock)
+	default:
+		t.Error("policy deleted notification not sent")
+	}
+}
+
+func TestSetPolicyInstanceStatusChanged(t *testing.T) {
+	statusPm := createPolicyManager(memSdl{
+		a1InstanceMetadataPrefix + "20001.654330": `[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"PENDING"}]`,
+	})
+
+	changed, err := statusPm.SetPolicyInstanceStatus(20001, "654330", "xapp1", "", "OK", "", "")
+	assert.Nil(t, err)
+	assert.True(t, changed)
+	changed, _ = statusPm.SetPolicyInstanceStatus(20001, "654330", "xapp2", "", "OK", "", "")
+	assert.False(t, changed)
+	// ALL_OK to PARTIAL
+	changed, _ = statusPm.SetPolicyInstanceStatus(20001, "654330", "xapp2", "", "ERROR", "SCOPE_NOT_APPLICABLE", "")
+	assert.True(t, changed)
+	changed, _ = statusPm.SetPolicyInstanceStatus(20001, "654330", "xapp1", "", "ERROR", "SCOPE_NOT_APPLICABLE", "cell not found")
+	assert.True(t, changed)
+	changed, _ = statusPm.SetPolicyInstanceStatus(20001, "654330", "xapp1", "", "ERROR", "SCOPE_NOT_APPLICABLE", "cell not found")
+	assert.False(t, changed)
+	// the enforce reason changes while the instance stays not enforced
+	changed, _ = statusPm.SetPolicyInstanceStatus(20001, "654330", "xapp1", "", "ERROR", "STATEMENT_NOT_APPLICABLE", "")
+	assert.True(t, changed)
+}
+
+func TestSetPolicyInstanceStatusConcurrentDelete(t *testing.T) {
+	instanceMetadataKey := a1InstanceMetadataPrefix + "20001.654331"
+	store := memSdl{instanceMetadataKey: `[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"PENDING"}]`}
+	// the instance is deleted between the read of its metadata and the write
+	racingPm := createPolicyManager(&racingSdl{memSdl: store, before: func() {
+		store[instanceMetadataKey] = `[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"DELETING"}]`
+	}})
+
+	_, err := racingPm.SetPolicyInstanceStatus(20001, "654331", "xapp1", "", "OK", "", "")
+
+	assert.True(t, IsInvalidStateTransition(err))
+	assert.Equal(t, InstanceStateDeleting, InstanceMetadataState(store[instanceMetadataKey].(string)))
+}
+
+func TestPolicyStatusBody(t *testing.T) {
+	handlers := map[string]HandlerStatus{
+		"xapp1": {Status: "ERROR", Reason: "SCOPE_NOT_APPLICABLE", Detail: "cell not found"},
+	}
+	status := PolicyStatusBody(false, InstanceStateNotEnforced, "ACKNOWLEDGED", handlers)
+	assert.Equal(t, "NOT_ENFORCED", status.EnforceStatus)
+	assert.Equal(t, "SCOPE_NOT_APPLICABLE", status.EnforceReason)
+	assert.Equal(t, "cell not found", status.EnforceDetail)
+	assert.Equal(t, AggregateStatusFailed, status.AggregateStatus)
+	assert.Equal(t, "ACKNOWLEDGED", status.AckStatus)
+	assert.Equal(t, 1, len(status.Handlers))
+
+	status = PolicyStatusBody(false, InstanceStateDeleted, "", nil)
+	assert.Equal(t, "NOT_ENFORCED", status.EnforceStatus)
+	assert.Equal(t, InstanceStateDeleted, status.InstanceState)
+	assert.Equal(t, "", status.EnforceReason)
+
+	status = PolicyStatusBody(true, InstanceStateEnforced, "", map[string]HandlerStatus{"xapp1": {Status: "OK"}})
+	assert.Equal(t, "ENFORCED", status.EnforceStatus)
+	assert.Equal(t, AggregateStatusAllOK, status.AggregateStatus)
+	assert.Equal(t, "", status.EnforceReason)
+}
+
+func TestDeletedInstanceMetadata(t *testing.T) {
+	metadata, err := DeletedInstanceMetadata(`[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"DELETING"}]`, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, InstanceStateDeleted, InstanceMetadataState(metadata))
+	assert.Contains(t, metadata, `"created_at":"2022-11-02 10:30:20"`)
+	assert.Contains(t, metadata, `"has_been_deleted":"True"`)
+}
+
+func TestIsStaleResponse(t *testing.T) {
+	transactionKey := a1TransactionPrefix + "20001.123456"
+	sdlInst.On("Get", "A1m_ns", []string{transactionKey}).Return(map[string]interface{}{}, nil).Times(3)
+
+	stale, err := pm.IsStaleResponse(20001, "123456", "0123456789abcdef", 0)
+	assert.NoError(t, err)
+	assert.False(t, stale)
+	stale, err = pm.IsStaleResponse(20001, "123456", "fedcba9876543210", 2)
+	assert.NoError(t, err)
+	assert.True(t, stale)
+	stale, err = pm.IsStaleResponse(20001, "123456", "", 1)
+	assert.NoError(t, err)
+	assert.True(t, stale)
+	stale, err = pm.IsStaleResponse(20001, "123456", "", 0)
+	assert.NoError(t, err)
+	assert.False(t, stale)
+}
+
+func TestRecordPolicyTypeHandler(t *testing.T) {
+	typeHandlerKey := a1TypeHandlerPrefix + "20001"
+	sdlInst.On("Get", "A1m_ns", []string{a1PolicyPrefix + "20001"}).Return(map[string]interface{}{}, nil).Once()
+	sdlInst.On("Get", "A1m_ns", []string{typeHandlerKey}).Return(map[string]interface{}{}, nil).Once()
+	recorded := mock.MatchedBy(func(pairs []interface{}) bool {
+		if len(pairs) != 2 || pairs[0] != typeHandlerKey {
+			return false
+		}
+		handlers, err := ParsePolicyTypeHandlers(pairs[1])
+		return err == nil && len(handlers) == 2 && handlers["xapp1"].FirstSeen == "2022-11-02 10:30:20" && handlers["xapp2"].LastStatus == "ERROR"
+	})
+	sdlInst.On("Set", "A1m_ns", recorded).Return(nil).Once()
+
+	pm.RecordPolicyTypeHandler(20001, "xapp2", "ERROR")
+
+	sdlInst.AssertCalled(t, "Set", "A1m_ns", recorded)
+}
+
+func TestRecordHandlerHeartbeat(t *testing.T) {
+	typeHandlerKey := a1TypeHandlerPrefix + "20001"
+	sdlInst.On("Get", "A1m_ns", []string{a1PolicyPrefix + "20001"}).Return(map[string]interface{}{}, nil).Once()
+	sdlInst.On("Get", "A1m_ns", []string{typeHandlerKey}).Return(map[string]interface{}{}, nil).Once()
+	recorded := mock.MatchedBy(func(pairs []interface{}) bool {
+		if len(pairs) != 2 || pairs[0] != typeHandlerKey {
+			return false
+		}
+		handlers, err := ParsePolicyTypeHandlers(pairs[1])
+		return err == nil && len(handlers) == 1 && handlers["xapp1"].LastStatus == "OK" && len(handlers["xapp1"].LastHeartbeat) > 0
+	})
+	sdlInst.On("Set", "A1m_ns", recorded).Return(nil).Once()
+
+	pm.RecordHandlerHeartbeat("xapp1", []int{20001})
+
+	sdlInst.AssertCalled(t, "Set", "A1m_ns", recorded)
+}
+
+func TestRecordPolicyTypeHandlerUnknownType(t *testing.T) {
+	store := memSdl{}
+	handlerPm := createPolicyManager(store)
+
+	err := handlerPm.RecordPolicyTypeHandler(20099, "xapp1", "OK")
+
+	assert.Equal(t, policyTypeNotFoundError, err)
+	assert.NotContains(t, store, a1TypeHandlerPrefix+"20099")
+}
+
+func TestRecordHandlerHeartbeatUnknownType(t *testing.T) {
+	store := memSdl{a1PolicyPrefix + "20001": `{"name":"admission_control_policy_mine"}`}
+	heartbeatPm := createPolicyManager(store)
+
+	assert.NoError(t, heartbeatPm.RecordHandlerHeartbeat("xapp1", []int{20099, 20001}))
+
+	assert.NotContains(t, store, a1TypeHandlerPrefix+"20099")
+	assert.Contains(t, store, a1TypeHandlerPrefix+"20001")
+}
+
+func TestLostPolicyTypeHandlers(t *testing.T) {
+	lastHeartbeat, _ := time.ParseInLocation("2006-01-02 15:04:05", "2022-11-02 10:30:20", time.Local)
+	typeHandlerKey := a1TypeHandlerPrefix + "20003"
+	sdlInst.On("GetAll", "A1m_ns").Return([]string{"a1.policy_type.20003", "a1.policy_instance.20003.1"}, nil).Twice()
+	sdlInst.On("Get", "A1m_ns", []string{typeHandlerKey}).Return(map[string]interface{}{}, nil).Twice()
+
+	lost, err := pm.LostPolicyTypeHandlers(30*time.Second, lastHeartbeat.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, []LostPolicyTypeHandler{{PolicyTypeId: 20003, HandlerId: "xapp1", LastHeartbeat: "2022-11-02 10:30:20"}}, lost)
+
+	lost, err = pm.LostPolicyTypeHandlers(2*time.Minute, lastHeartbeat.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Empty(t, lost)
+}
+
+func TestMarkPolicyTypeHandlerLost(t *testing.T) {
+	typeHandlerKey := a1TypeHandlerPrefix + "20003"
+	sdlInst.On("Get", "A1m_ns", []string{typeHandlerKey}).Return(map[string]interface{}{}, nil).Twice()
+
+	// the handler sent a heartbeat since it was found lost
+	instances, err := pm.MarkPolicyTypeHandlerLost(LostPolicyTypeHandler{PolicyTypeId: 20003, HandlerId: "xapp1", LastHeartbeat: "2022-11-02 10:29:20"}, "")
+	assert.NoError(t, err)
+	assert.Empty(t, instances)
+
+	marked := mock.MatchedBy(func(pairs []interface{}) bool {
+		if len(pairs) != 2 || pairs[0] != typeHandlerKey {
+			return false
+		}
+		handlers, err := ParsePolicyTypeHandlers(pairs[1])
+		return err == nil && handlers["xapp1"].Lost && !handlers["xapp2"].Lost
+	})
+	sdlInst.On("Set", "A1m_ns", marked).Return(errors.New("Some Error")).Once()
+	_, err = pm.MarkPolicyTypeHandlerLost(LostPolicyTypeHandler{PolicyTypeId: 20003, HandlerId: "xapp1", LastHeartbeat: "2022-11-02 10:30:20"}, "no heartbeat")
+	assert.Error(t, err)
+	sdlInst.AssertCalled(t, "Set", "A1m_ns", marked)
+}
+
+func TestHandlerStatusListForRanNodes(t *testing.T) {
+	handlers := map[string]HandlerStatus{
+		HandlerStatusKey("xapp1", "gnb_002"): {Status: "ERROR", RanName: "gnb_002"},
+		HandlerStatusKey("xapp1", "gnb_001"): {Status: "OK", RanName: "gnb_001"},
+		HandlerStatusKey("xapp2", ""):        {Status: "OK"},
+	}
+	list := HandlerStatusList(handlers)
+	assert.Equal(t, 3, len(list))
+	assert.Equal(t, "xapp1", list[0].HandlerID)
+	assert.Equal(t, "gnb_001", list[0].RanName)
+	assert.Equal(t, "xapp1", list[1].HandlerID)
+	assert.Equal(t, "gnb_002", list[1].RanName)
+	assert.Equal(t, "ERROR", list[1].Status)
+	assert.Equal(t, "xapp2", list[2].HandlerID)
+	assert.Equal(t, "", list[2].RanName)
+}
+
+func TestInstanceMetadataRanNames(t *testing.T) {
+	assert.Equal(t, []string{"gnb_001", "gnb_002"}, InstanceMetadataRanNames(`[{"created_at":"2022-11-02 10:30:20","state":"PENDING","ran_names":["gnb_001","gnb_002"]}]`))
+	assert.Nil(t, InstanceMetadataRanNames(`[{"created_at":"2022-11-02 10:30:20","state":"PENDING"}]`))
+	assert.Nil(t, InstanceMetadataRanNames(`{"created_at":"2022-11-02 10:30:20","state":"DELETED"}`))
+}
+
+func TestPolicyTypeHandlerList(t *testing.T) {
+	handlers, err := ParsePolicyTypeHandlers(`{"xapp2":{"first_seen":"2022-11-02 10:30:20","last_seen":"2022-11-02 10:30:21","last_status":"OK"},"xapp1":{"first_seen":"2022-11-02 10:30:20","last_seen":"2022-11-02 10:30:22","last_status":"ERROR"}}`)
+	assert.NoError(t, err)
+	list := PolicyTypeHandlerList(handlers)
+	assert.Equal(t, 2, len(list))
+	assert.Equal(t, "xapp1", list[0].HandlerID)
+	assert.Equal(t, "ERROR", list[0].LastStatus)
+	assert.Equal(t, "xapp2", list[1].HandlerID)
+}
+
+func TestHandlerStatusList(t *testing.T) {
+	handlers, err := ParseHandlerStatus(`{"xapp2":{"status":"ERROR","updated_at":"2022-11-02 10:30:20"},"xapp1":{"status":"OK","updated_at":"2022-11-02 10:30:21"}}`)
+	assert.NoError(t, err)
+	list := HandlerStatusList(handlers)
+	assert.Equal(t, 2, len(list))
+	assert.Equal(t, "xapp1", list[0].HandlerID)
+	assert.Equal(t, "OK", list[0].Status)
+	assert.Equal(t, "xapp2", list[1].HandlerID)
+}
+
+func TestStatusHistoryList(t *testing.T) {
+	history, err := ParseStatusHistory(`[{"handler_id":"xapp1","previous_status":"","status":"OK","instance_status":"IN EFFECT","changed_at":"2022-11-02 10:30:20"},{"handler_id":"xapp1","ran_name":"gnb_001","previous_status":"OK","status":"ERROR","reason":"SCOPE_NOT_APPLICABLE","instance_status":"NOT IN EFFECT","changed_at":"2022-11-02 10:30:21"}]`)
+	assert.NoError(t, err)
+	list := StatusHistoryList(history)
+	assert.Equal(t, 2, len(list))
+	assert.Equal(t, "OK", list[0].Status)
+	assert.Equal(t, "OK", list[1].PreviousStatus)
+	assert.Equal(t, "ERROR", list[1].Status)
+	assert.Equal(t, "gnb_001", list[1].RanName)
+	assert.Equal(t, "SCOPE_NOT_APPLICABLE", list[1].EnforceReason)
+	history, err = ParseStatusHistory(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, len(history))
+	_, err = ParseStatusHistory("{")
+	assert.Error(t, err)
+}
+
+func TestRecordPolicyFeedback(t *testing.T) {
+	instanceKey := a1InstancePrefix + "20001.123456"
+	feedbackKey := a1FeedbackPrefix + "20001.123456"
+	sdlInst.On("Get", "A1m_ns", []string{instanceKey, feedbackKey}).Return(map[string]interface{}{}, nil).Once()
+	recorded := mock.MatchedBy(func(pairs []interface{}) bool {
+		if len(pairs) != 2 || pairs[0] != feedbackKey {
+			return false
+		}
+		samples, err := ParsePolicyFeedback(pairs[1])
+		return err == nil && len(samples) == 1 && samples[0].Kpis["throughput"] == 12.5 && len(samples[0].ReceivedAt) > 0
+	})
+	sdlInst.On("Set", "A1m_ns", recorded).Return(nil).Once()
+
+	pm.RecordPolicyFeedback(20001, "123456", PolicyFeedback{HandlerID: "xapp1", Kpis: map[string]float64{"throughput": 12.5}}, 10)
+
+	sdlInst.AssertCalled(t, "Set", "A1m_ns", recorded)
+}
+
+func TestRecordPolicyFeedbackCap(t *testing.T) {
+	samples := make([]PolicyFeedback, maxPolicyFeedbackSamples)
+	data, _ := json.Marshal(samples)
+	feedbackKey := a1FeedbackPrefix + "20001.654332"
+	store := memSdl{a1InstancePrefix + "20001.654332": `{"enforce":true}`, feedbackKey: string(data)}
+	feedbackPm := createPolicyManager(store)
+
+	err := feedbackPm.RecordPolicyFeedback(20001, "654332", PolicyFeedback{HandlerID: "xapp1", Kpis: map[string]float64{"throughput": 12.5}}, 0)
+
+	assert.NoError(t, err)
+	samples, _ = ParsePolicyFeedback(store[feedbackKey])
+	assert.Equal(t, maxPolicyFeedbackSamples, len(samples))
+	assert.Equal(t, "xapp1", samples[len(samples)-1].HandlerID)
+}
+
+func TestRecordPolicyFeedbackUnknownInstance(t *testing.T) {
+	keys := []string{a1InstancePrefix + "20001.999999", a1FeedbackPrefix + "20001.999999"}
+	sdlInst.On("Get", "A1m_ns", keys).Return(map[string]interface{}{}, nil).Once()
+	err := pm.RecordPolicyFeedback(20001, "999999", PolicyFeedback{HandlerID: "xapp1", Kpis: map[string]float64{"throughput": 12.5}}, 10)
+	assert.True(t, IsPolicyInstanceNotFound(err))
+}
+
+func TestPolicyFeedbackList(t *testing.T) {
+	samples, err := ParsePolicyFeedback(`[{"handler_id":"xapp1","kpis":{"throughput":12.5},"received_at":"2022-11-02 10:30:20"},{"handler_id":"xapp1","ran_name":"gnb_001","kpis":{"throughput":13,"latency":2},"measured_at":"2022-11-02 10:30:59","received_at":"2022-11-02 10:31:00"}]`)
+	assert.NoError(t, err)
+	list := PolicyFeedbackList(samples)
+	assert.Equal(t, 2, len(list))
+	assert.Equal(t, 12.5, list[0].Kpis["throughput"])
+	assert.Equal(t, "gnb_001", list[1].RanName)
+	assert.Equal(t, 2.0, list[1].Kpis["latency"])
+	assert.Equal(t, "2022-11-02 10:30:59", list[1].MeasuredAt)
+	_, err = ParsePolicyFeedback("[")
+	assert.Error(t, err)
+}
+
+func TestSweepMessagePayloads(t *testing.T) {
+	store := memSdl{}
+	mpm := createPolicyManager(store)
+	ref, err := mpm.StoreMessagePayload("valid", []byte(`{"enforce":true}`), time.Minute)
+	assert.Nil(t, err)
+	_, err = mpm.StoreMessagePayload("expired", []byte(`{"enforce":false}`), -time.Minute)
+	assert.Nil(t, err)
+	store[a1MessagePayloadPrefix+"orphan"] = `{}`
+	store[a1MessagePayloadExpiryPrefix+"lost"] = ref.ExpiresAt
+
+	refs, err := mpm.SweepMessagePayloads(time.Now())
+
+	assert.Nil(t, err)
+	assert.Equal(t, []*MessagePayloadRef{ref}, refs)
+	assert.Equal(t, 2, len(store))
+	assert.Nil(t, mpm.RemoveMessagePayload(ref))
+	assert.Equal(t, 0, len(store))
+}
+
 func TestGetAllPolicyIntances(t *testing.T) {
 	var policyTypeId int
 	policyTypeId = 20005
@@ -329,11 +752,24 @@ func TestGetAllPolicyIntancesFail2(t *testing.T) {
 }
 
 
+func TestGetAllPolicyType(t *testing.T) {
+	keys := []string{"a1.policy_instance.1006001.qos", "a1.policy_type.20000", "a1.policy_type_handler.20000", "a1.policy_type.1006001"}
+	sdlInst.On("GetAll", "A1m_ns").Return(keys, nil).Once()
+	resp, err := pm.GetAllPolicyType()
+	assert.NoError(t, err)
+	assert.Equal(t, []int{20000, 1006001}, resp)
+}
+
 func (s *SdlMock) Set(ns string, pairs ...interface{}) error {
 	args := s.MethodCalled("Set", ns, pairs)
 	return args.Error(0)
 }
 
+func (s *SdlMock) SetIf(ns string, key string, oldData, newData interface{}) (bool, error) {
+	args := s.MethodCalled("SetIf", ns, key, oldData, newData)
+	return args.Bool(0), args.Error(1)
+}
+
 
 func (s *SdlMock) Get(ns string, keys []string) (map[string]interface{}, error) {
         a1.Logger.Debug("Mock:Get Called ")
@@ -355,8 +791,20 @@ func (s *SdlMock) Get(ns string, keys []string) (map[string]interface{}, error)
                 policySchemaString = "testval"
                 key = a1HandlerPrefix + strconv.FormatInt(20001, 10) + "." + "123456"
         } else if keys[0] == "a1.policy_inst_metadata.20001.123456" {
-                policySchemaString = "testval"
+                policySchemaString = `[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"PENDING"}]`
                 key = a1InstanceMetadataPrefix + strconv.FormatInt(20001, 10) + "." + "123456"
+        } else if keys[0] == "a1.policy_inst_metadata.20001.654321" {
+                policySchemaString = `[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"DELETING"}]`
+                key = a1InstanceMetadataPrefix + strconv.FormatInt(20001, 10) + "." + "654321"
+        } else if keys[0] == "a1.policy_type_handler.20001" {
+                policySchemaString = `{"xapp1":{"first_seen":"2022-11-02 10:30:20","last_seen":"2022-11-02 10:30:20","last_status":"OK"}}`
+                key = a1TypeHandlerPrefix + strconv.FormatInt(20001, 10)
+        } else if keys[0] == "a1.policy_type_handler.20003" {
+                policySchemaString = `{"xapp1":{"first_seen":"2022-11-02 10:30:20","last_seen":"2022-11-02 10:30:20","last_status":"OK","last_heartbeat":"2022-11-02 10:30:20"},"xapp2":{"first_seen":"2022-11-02 10:30:20","last_seen":"2022-11-02 10:30:20","last_status":"OK"},"xapp3":{"first_seen":"2022-11-02 10:30:20","last_seen":"2022-11-02 10:30:20","last_status":"OK","last_heartbeat":"2022-11-02 10:30:20","lost":true}}`
+                key = a1TypeHandlerPrefix + strconv.FormatInt(20003, 10)
+        } else if keys[0] == "a1.policy_transaction.20001.123456" {
+                policySchemaString = `{"transaction_id":"0123456789abcdef","revision":2,"operation":"UPDATE","sent_at":"2022-11-02 10:30:20"}`
+                key = a1TransactionPrefix + strconv.FormatInt(20001, 10) + "." + "123456"
         } else if keys[0] == "a1.policy_notification_destination.20000.12345" {
                 policySchemaString = "www.xyz.com"
                 key = a1NotificationDestinationPrefix + strconv.FormatInt((int64(20000)), 10) + "." + "12345"
@@ -368,11 +816,74 @@ func (s *SdlMock) Get(ns string, keys []string) (map[string]interface{}, error)
         return mp, args.Error(1)
 }
 
+// memSdl keeps the data in memory and returns each key's own content
+type memSdl map[string]interface{}
+
+func (s memSdl) Set(ns string, pairs ...interface{}) error {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		s[pairs[i].(string)] = pairs[i+1]
+	}
+	return nil
+}
+
+func (s memSdl) SetIf(ns string, key string, oldData, newData interface{}) (bool, error) {
+	if s[key] != oldData {
+		return false, nil
+	}
+	s[key] = newData
+	return true, nil
+}
+
+// racingSdl runs before ahead of its first compare-and-set, as a write made
+// concurrently by another component would
+type racingSdl struct {
+	memSdl
+	before func()
+}
+
+func (s *racingSdl) SetIf(ns string, key string, oldData, newData interface{}) (bool, error) {
+	if s.before != nil {
+		s.before()
+		s.before = nil
+	}
+	return s.memSdl.SetIf(ns, key, oldData, newData)
+}
+
+func (s memSdl) Get(ns string, keys []string) (map[string]interface{}, error) {
+	mp := map[string]interface{}{}
+	for _, key := range keys {
+		if value, ok := s[key]; ok {
+			mp[key] = value
+		}
+	}
+	return mp, nil
+}
+
+func (s memSdl) GetAll(ns string) ([]string, error) {
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (s memSdl) Remove(ns string, keys []string) error {
+	for _, key := range keys {
+		delete(s, key)
+	}
+	return nil
+}
+
 func (s *SdlMock) GetAll(ns string) ([]string, error) {
 	args := s.MethodCalled("GetAll", ns)
 	return args.Get(0).([]string), args.Error(1)
 }
 
+func (s *SdlMock) Remove(ns string, keys []string) error {
+	args := s.MethodCalled("Remove", ns, keys)
+	return args.Error(0)
+}
+
 func (s *SdlMock) RemoveAll(ns string) error {
         //args := s.MethodCalled("RemoveAll", ns)
         return nil
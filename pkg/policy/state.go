@@ -0,0 +1,217 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/restapi/operations/a1_mediator"
+)
+
+const (
+	InstanceStatePending     = a1_mediator.A1ControllerGetPolicyInstanceStatusOKBodyInstanceStatePENDING
+	InstanceStateEnforced    = a1_mediator.A1ControllerGetPolicyInstanceStatusOKBodyInstanceStateENFORCED
+	InstanceStateNotEnforced = a1_mediator.A1ControllerGetPolicyInstanceStatusOKBodyInstanceStateNOTENFORCED
+	InstanceStateDeleting    = a1_mediator.A1ControllerGetPolicyInstanceStatusOKBodyInstanceStateDELETING
+	InstanceStateDeleted     = a1_mediator.A1ControllerGetPolicyInstanceStatusOKBodyInstanceStateDELETED
+	SchedulePending          = a1_mediator.A1ControllerGetPolicyInstanceStatusOKBodyScheduleStatusPENDING
+)
+
+var invalidStateTransitionError = errors.New("Invalid policy instance state transition")
+var metadataConflictError = errors.New("Policy instance metadata changed concurrently")
+
+// maxMetadataUpdateAttempts bounds how often an update of the instance metadata
+// is retried when the metadata keeps changing in between
+const maxMetadataUpdateAttempts = 5
+
+// MetadataStore is the part of the SDL the instance metadata is updated through
+type MetadataStore interface {
+	Get(string, []string) (map[string]interface{}, error)
+	SetIf(ns string, key string, oldData, newData interface{}) (bool, error)
+	SetIfNotExists(ns string, key string, data interface{}) (bool, error)
+}
+
+// instanceStateTransitions lists the states each state may move to. Metadata
+// written before the state machine existed has no state and may move anywhere.
+var instanceStateTransitions = map[string][]string{
+	InstanceStatePending:     {InstanceStatePending, InstanceStateEnforced, InstanceStateNotEnforced, InstanceStateDeleting},
+	InstanceStateEnforced:    {InstanceStatePending, InstanceStateEnforced, InstanceStateNotEnforced, InstanceStateDeleting},
+	InstanceStateNotEnforced: {InstanceStatePending, InstanceStateEnforced, InstanceStateNotEnforced, InstanceStateDeleting},
+	InstanceStateDeleting:    {InstanceStateDeleting, InstanceStateDeleted},
+	InstanceStateDeleted:     {InstanceStatePending},
+}
+
+// ValidateStateTransition checks that a policy instance may move between the states
+func ValidateStateTransition(from string, to string) error {
+	if len(from) == 0 {
+		return nil
+	}
+	for _, state := range instanceStateTransitions[from] {
+		if state == to {
+			return nil
+		}
+	}
+	return invalidStateTransitionError
+}
+
+func IsInvalidStateTransition(err error) bool {
+	return err == invalidStateTransitionError
+}
+
+func IsMetadataConflict(err error) bool {
+	return err == metadataConflictError
+}
+
+// UpdateInstanceMetadata writes the metadata update derives from metadata, the
+// metadata last read from key, with a compare-and-set so a state change stored
+// meanwhile, e.g. by a policy response, is never overwritten. When the metadata
+// changed, update is applied again to the stored metadata. Nothing is written
+// when update returns the metadata unchanged.
+func UpdateInstanceMetadata(db MetadataStore, key string, metadata string, update func(metadata string) (string, error)) (string, error) {
+	for attempt := 1; ; attempt++ {
+		updated, err := update(metadata)
+		if err != nil {
+			return "", err
+		}
+		if updated == metadata {
+			return metadata, nil
+		}
+		var stored bool
+		if len(metadata) == 0 {
+			stored, err = db.SetIfNotExists(a1MediatorNs, key, updated)
+		} else {
+			stored, err = db.SetIf(a1MediatorNs, key, metadata, updated)
+		}
+		if err != nil {
+			a1.Logger.Error("error :%+v", err)
+			return "", err
+		}
+		if stored {
+			return updated, nil
+		}
+		if attempt == maxMetadataUpdateAttempts {
+			a1.Logger.Error("%s changed concurrently %d times, giving up", key, attempt)
+			return "", metadataConflictError
+		}
+		valmap, err := db.Get(a1MediatorNs, []string{key})
+		if err != nil {
+			a1.Logger.Error("policy instance error : %v", err)
+			return "", err
+		}
+		metadata, _ = valmap[key].(string)
+	}
+}
+
+// InstanceMetadataState returns the state kept in the instance metadata, which
+// is stored as a list for live instances and as a single object once deleted
+func InstanceMetadataState(metadata string) string {
+	var metadataList []map[string]interface{}
+	if err := json.Unmarshal([]byte(metadata), &metadataList); err == nil {
+		if len(metadataList) > 0 {
+			state, _ := metadataList[0]["state"].(string)
+			return state
+		}
+		return ""
+	}
+	var metadataMap map[string]interface{}
+	if err := json.Unmarshal([]byte(metadata), &metadataMap); err == nil {
+		state, _ := metadataMap["state"].(string)
+		return state
+	}
+	return ""
+}
+
+// SetInstanceMetadataState returns the metadata with its state replaced, keeping
+// the stored layout
+func SetInstanceMetadataState(metadata string, state string) (string, error) {
+	var metadataList []map[string]interface{}
+	if err := json.Unmarshal([]byte(metadata), &metadataList); err == nil {
+		if len(metadataList) == 0 {
+			metadataList = append(metadataList, map[string]interface{}{})
+		}
+		metadataList[0]["state"] = state
+		data, err := json.Marshal(metadataList)
+		return string(data), err
+	}
+	var metadataMap map[string]interface{}
+	if err := json.Unmarshal([]byte(metadata), &metadataMap); err != nil {
+		return "", err
+	}
+	metadataMap["state"] = state
+	data, err := json.Marshal(metadataMap)
+	return string(data), err
+}
+
+// DeletedInstanceMetadata returns the metadata kept once the instance is removed,
+// which records when it was created and deleted
+func DeletedInstanceMetadata(metadata string, deletedAt time.Time) (string, error) {
+	var createdAt string
+	var metadataList []map[string]interface{}
+	if err := json.Unmarshal([]byte(metadata), &metadataList); err == nil {
+		if len(metadataList) > 0 {
+			createdAt, _ = metadataList[0]["created_at"].(string)
+		}
+	} else {
+		var metadataMap map[string]interface{}
+		if err := json.Unmarshal([]byte(metadata), &metadataMap); err != nil {
+			return "", err
+		}
+		createdAt, _ = metadataMap["created_at"].(string)
+	}
+	data, err := json.Marshal(map[string]string{"created_at": createdAt, "has_been_deleted": "True", "deleted_at": deletedAt.Format("2006-01-02 15:04:05"), "state": InstanceStateDeleted})
+	return string(data), err
+}
+
+// IsSchedulePending tells whether the stored schedule of an instance waits for
+// its validity window to start
+func IsSchedulePending(schedule string) bool {
+	var scheduleMap map[string]interface{}
+	if err := json.Unmarshal([]byte(schedule), &scheduleMap); err != nil {
+		return false
+	}
+	status, _ := scheduleMap["status"].(string)
+	return status == SchedulePending
+}
+
+// IsPolicyInstanceActive tells whether the instance is to be enforced by the
+// xApps, that is it is neither being deleted nor deleted and its validity
+// window, if any, has started
+func (pm *PolicyManager) IsPolicyInstanceActive(policyTypeId int, policyInstanceID string) (bool, error) {
+	suffix := strconv.FormatInt((int64(policyTypeId)), 10) + "." + policyInstanceID
+	metadataKey, scheduleKey := a1InstanceMetadataPrefix+suffix, a1SchedulePrefix+suffix
+	valmap, err := pm.db.Get(a1MediatorNs, []string{metadataKey, scheduleKey})
+	if err != nil {
+		a1.Logger.Error("error in retrieving policy instance state err: %v", err)
+		return false, err
+	}
+	metadata, _ := valmap[metadataKey].(string)
+	state := InstanceMetadataState(metadata)
+	if state == InstanceStateDeleting || state == InstanceStateDeleted {
+		return false, nil
+	}
+	schedule, _ := valmap[scheduleKey].(string)
+	return !IsSchedulePending(schedule), nil
+}
@@ -0,0 +1,64 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package policy
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const ranNameSeparator = "@"
+
+// HandlerStatusKey returns the key of the status a handler reports for one RAN
+// node of an instance. The status of an instance sent to every xApp is only
+// keyed by the handler id.
+func HandlerStatusKey(handlerId string, ranName string) string {
+	if len(ranName) == 0 {
+		return handlerId
+	}
+	return handlerId + ranNameSeparator + ranName
+}
+
+// handlerIdOf returns the handler id of a handler status key
+func handlerIdOf(key string, handler HandlerStatus) string {
+	if len(handler.RanName) == 0 {
+		return key
+	}
+	return strings.TrimSuffix(key, ranNameSeparator+handler.RanName)
+}
+
+// InstanceMetadataRanNames returns the RAN nodes a live instance is sent to,
+// none when it is sent to every xApp
+func InstanceMetadataRanNames(metadata string) []string {
+	var metadataList []map[string]interface{}
+	if err := json.Unmarshal([]byte(metadata), &metadataList); err != nil || len(metadataList) == 0 {
+		return nil
+	}
+	names, _ := metadataList[0]["ran_names"].([]interface{})
+	var ranNames []string
+	for _, name := range names {
+		if ranName, ok := name.(string); ok && len(ranName) > 0 {
+			ranNames = append(ranNames, ranName)
+		}
+	}
+	return ranNames
+}
@@ -0,0 +1,71 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package policy
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+)
+
+// ParsePolicyTransaction decodes the outstanding request record stored in SDL,
+// nil is returned when the instance has no request recorded
+func ParsePolicyTransaction(data interface{}) (*PolicyTransaction, error) {
+	var transaction PolicyTransaction
+	str, ok := data.(string)
+	if !ok || len(str) == 0 {
+		return nil, nil
+	}
+	if err := json.Unmarshal([]byte(str), &transaction); err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
+// GetPolicyTransaction returns the last A1_POLICY_REQ sent for the instance
+func (pm *PolicyManager) GetPolicyTransaction(policyTypeId int, policyInstanceID string) (*PolicyTransaction, error) {
+	transactionKey := a1TransactionPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + policyInstanceID
+	resp, err := pm.db.Get(a1MediatorNs, []string{transactionKey})
+	if err != nil {
+		a1.Logger.Error("error1 :%+v", err)
+		return nil, err
+	}
+	return ParsePolicyTransaction(resp[transactionKey])
+}
+
+// IsStaleResponse tells whether an A1_POLICY_RESP answers an older request than
+// the one outstanding for the instance. The transaction id is matched when the
+// xApp echoes it, the revision otherwise. Responses carrying neither are accepted.
+func (pm *PolicyManager) IsStaleResponse(policyTypeId int, policyInstanceID string, transactionId string, revision int64) (bool, error) {
+	if len(transactionId) == 0 && revision == 0 {
+		return false, nil
+	}
+	transaction, err := pm.GetPolicyTransaction(policyTypeId, policyInstanceID)
+	if err != nil || transaction == nil {
+		return false, err
+	}
+	if len(transactionId) > 0 {
+		return transactionId != transaction.TransactionID, nil
+	}
+	return revision < transaction.Revision, nil
+}
@@ -21,11 +21,83 @@
 
 package policy
 
+import "sync"
+
 type PolicyManager struct {
-	db iSdl
+	db    iSdl
+	mutex sync.Mutex
+}
+
+// HandlerStatus is the last status reported by one xApp handler of a policy instance
+type HandlerStatus struct {
+	Status    string `json:"status"`
+	Reason    string `json:"reason,omitempty"`
+	Detail    string `json:"detail,omitempty"`
+	UpdatedAt string `json:"updated_at"`
+	RanName   string `json:"ran_name,omitempty"`
+}
+
+// StatusChange is a change of the status a handler reports for a policy instance
+type StatusChange struct {
+	HandlerID      string `json:"handler_id"`
+	RanName        string `json:"ran_name,omitempty"`
+	PreviousStatus string `json:"previous_status,omitempty"`
+	Status         string `json:"status"`
+	Reason         string `json:"reason,omitempty"`
+	Detail         string `json:"detail,omitempty"`
+	InstanceStatus string `json:"instance_status"`
+	ChangedAt      string `json:"changed_at"`
+}
+
+// PolicyFeedback is a sample of the KPIs an xApp measured for a policy instance
+type PolicyFeedback struct {
+	HandlerID  string             `json:"handler_id"`
+	RanName    string             `json:"ran_name,omitempty"`
+	Kpis       map[string]float64 `json:"kpis"`
+	MeasuredAt string             `json:"measured_at,omitempty"`
+	ReceivedAt string             `json:"received_at"`
 }
+
+// PolicyTypeHandler is an xApp that reported the status of an instance of a policy
+// type. Only the handlers that send heartbeats have a last heartbeat, they are
+// lost once they stay silent for too long.
+type PolicyTypeHandler struct {
+	FirstSeen     string `json:"first_seen"`
+	LastSeen      string `json:"last_seen"`
+	LastStatus    string `json:"last_status"`
+	LastHeartbeat string `json:"last_heartbeat,omitempty"`
+	Lost          bool   `json:"lost,omitempty"`
+}
+
+// PolicyTransaction is the last A1_POLICY_REQ sent for a policy instance, the
+// xApps echo its transaction id and revision in their A1_POLICY_RESP. The
+// revision changes with the content of the instance while the sequence number
+// counts every request sent for it. The request is sent once for every RAN node
+// of the instance, or to every xApp when it has none.
+type PolicyTransaction struct {
+	TransactionID string   `json:"transaction_id"`
+	Revision      int64    `json:"revision"`
+	Sequence      int64    `json:"sequence"`
+	Operation     string   `json:"operation"`
+	CreatedAt     string   `json:"created_at"`
+	UpdatedAt     string   `json:"updated_at"`
+	SentAt        string   `json:"sent_at"`
+	RanNames      []string `json:"ran_names,omitempty"`
+}
+
+// MessagePayloadRef locates in SDL the payload of an RMR message that is too
+// large to be sent, the xApps read the payload from there until it expires
+type MessagePayloadRef struct {
+	Namespace string `json:"namespace"`
+	Key       string `json:"key"`
+	Size      int    `json:"size"`
+	ExpiresAt string `json:"expires_at"`
+}
+
 type iSdl interface {
 	Set(ns string, pairs ...interface{}) error
+	SetIf(ns string, key string, oldData, newData interface{}) (bool, error)
 	GetAll(string) ([]string, error)
 	Get(string, []string) (map[string]interface{}, error)
+	Remove(string, []string) error
 }
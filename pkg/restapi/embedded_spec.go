@@ -178,6 +178,46 @@ func init() {
         }
       ]
     },
+    "/A1-P/v2/policytypes/{policy_type_id}/handlers": {
+      "get": {
+        "description": "Retrieve the xApps that reported the status of a policy instance of this policy type\n",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "A1 Mediator"
+        ],
+        "operationId": "a1.controller.get_policy_type_handlers",
+        "responses": {
+          "200": {
+            "description": "successfully retrieved the handlers of the policy type\n",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/policy_type_handler"
+              }
+            }
+          },
+          "404": {
+            "description": "there is no policy type with this policy_type_id\n"
+          },
+          "503": {
+            "description": "Potentially transient backend database error. Client should attempt to retry later."
+          }
+        }
+      },
+      "parameters": [
+        {
+          "maximum": 2147483647,
+          "minimum": 1,
+          "type": "integer",
+          "description": "represents a policy type identifier. Currently this is restricted to an integer range.\n",
+          "name": "policy_type_id",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
     "/A1-P/v2/policytypes/{policy_type_id}/policies": {
       "get": {
         "description": "get a list of all policy instance ids for this policy type id",
@@ -263,6 +303,28 @@ func init() {
               "description": "the schema of this object is defined by the create_schema field of the policy type\n",
               "type": "object"
             }
+          },
+          {
+            "type": "string",
+            "description": "RFC 3339 timestamp from which the policy instance is in effect. The instance is only sent to the xApps once this time is reached\n",
+            "name": "validFrom",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "RFC 3339 timestamp at which the policy instance expires. The instance is deleted from the xApps once this time is reached\n",
+            "name": "validUntil",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "csv",
+            "description": "names of the RAN nodes the policy instance applies to. The instance is sent once for every node, with the node set as the RMR Meid. It is sent to every xApp when no node is given\n",
+            "name": "ranNames",
+            "in": "query"
           }
         ],
         "responses": {
@@ -275,17 +337,28 @@ func init() {
           "404": {
             "description": "There is no policy type with this policy_type_id\n"
           },
+          "409": {
+            "description": "The policy instance is being deleted\n"
+          },
           "503": {
             "description": "Potentially transient backend database error. Client should attempt to retry later."
           }
         }
       },
       "delete": {
-        "description": "Delete this policy instance\n",
+        "description": "Delete this policy instance. The instance moves to the DELETING state and is removed once the xApps acknowledge the deletion or the delete timeout expires.\n",
         "tags": [
           "A1 Mediator"
         ],
         "operationId": "a1.controller.delete_policy_instance",
+        "parameters": [
+          {
+            "type": "boolean",
+            "description": "Remove the policy instance right away instead of waiting for the xApps to acknowledge the deletion\n",
+            "name": "force",
+            "in": "query"
+          }
+        ],
         "responses": {
           "202": {
             "description": "policy instance deletion initiated\n"
@@ -323,6 +396,53 @@ func init() {
         }
       ]
     },
+    "/A1-P/v2/policytypes/{policy_type_id}/policies/{policy_instance_id}/feedback": {
+      "get": {
+        "description": "Retrieve the feedback samples reported by the xApps on the performance of the policy instance, oldest first\n",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "A1 Mediator"
+        ],
+        "operationId": "a1.controller.get_policy_instance_feedback",
+        "responses": {
+          "200": {
+            "description": "successfully retrieved the policy feedback\n",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/policy_feedback"
+              }
+            }
+          },
+          "404": {
+            "description": "there is no policy instance with this policy_instance_id or there is no policy type with this policy_type_id\n"
+          },
+          "503": {
+            "description": "Potentially transient backend database error. Client should attempt to retry later."
+          }
+        }
+      },
+      "parameters": [
+        {
+          "maximum": 2147483647,
+          "minimum": 1,
+          "type": "integer",
+          "description": "represents a policy type identifier. Currently this is restricted to an integer range.\n",
+          "name": "policy_type_id",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "represents a policy instance identifier. UUIDs are advisable but can be any string\n",
+          "name": "policy_instance_id",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
     "/A1-P/v2/policytypes/{policy_type_id}/policies/{policy_instance_id}/status": {
       "get": {
         "description": "Retrieve the policy instance status across all handlers of the policy If this endpoint returns successfully (200), it is either IN EFFECT or NOT IN EFFECT. IN EFFECT is returned if at least one policy handler in the RIC is implementing the policy NOT IN EFFECT is returned otherwise If a policy instance is successfully deleted, this endpoint will return a 404 (not a 200)\n",
@@ -339,6 +459,25 @@ func init() {
             "schema": {
               "type": "object",
               "properties": {
+                "ackStatus": {
+                  "type": "string",
+                  "enum": [
+                    "PENDING",
+                    "ACKNOWLEDGED",
+                    "NOT_ACKNOWLEDGED"
+                  ]
+                },
+                "aggregateStatus": {
+                  "type": "string",
+                  "enum": [
+                    "ALL_OK",
+                    "PARTIAL",
+                    "FAILED"
+                  ]
+                },
+                "enforceDetail": {
+                  "type": "string"
+                },
                 "enforceReason": {
                   "type": "string",
                   "enum": [
@@ -353,6 +492,35 @@ func init() {
                     "ENFORCED",
                     "NOT_ENFORCED"
                   ]
+                },
+                "handlers": {
+                  "type": "array",
+                  "items": {
+                    "$ref": "#/definitions/policy_handler_status"
+                  }
+                },
+                "instanceState": {
+                  "type": "string",
+                  "enum": [
+                    "PENDING",
+                    "ENFORCED",
+                    "NOT_ENFORCED",
+                    "DELETING",
+                    "DELETED"
+                  ]
+                },
+                "scheduleStatus": {
+                  "type": "string",
+                  "enum": [
+                    "PENDING",
+                    "ACTIVE"
+                  ]
+                },
+                "validFrom": {
+                  "type": "string"
+                },
+                "validUntil": {
+                  "type": "string"
                 }
               }
             }
@@ -384,164 +552,577 @@ func init() {
         }
       ]
     },
-    "/data-delivery": {
-      "post": {
-        "description": "Deliver data produced by data producer.\n",
-        "consumes": [
-          "application/json"
-        ],
-        "tags": [
-          "A1 EI Data Delivery"
-        ],
-        "operationId": "a1.controller.data_delivery",
-        "parameters": [
-          {
-            "name": "body",
-            "in": "body",
-            "schema": {
-              "description": "object to represent data object\n",
-              "type": "object"
-            }
-          }
-        ],
-        "responses": {
-          "200": {
-            "description": "successfully delivered data from data producer\n"
-          },
-          "404": {
-            "description": "no job id defined for this data delivery\n"
-          }
-        }
-      }
-    }
-  },
-  "definitions": {
-    "policy_instance_id": {
-      "description": "represents a policy instance identifier. UUIDs are advisable but can be any string\n",
-      "type": "string",
-      "example": "3d2157af-6a8f-4a7c-810f-38c2f824bf12"
-    },
-    "policy_type_id": {
-      "description": "represents a policy type identifier. Currently this is restricted to an integer range.\n",
-      "type": "integer",
-      "maximum": 2147483647,
-      "minimum": 1
-    },
-    "policy_type_schema": {
-      "type": "object",
-      "required": [
-        "name",
-        "description",
-        "policy_type_id",
-        "create_schema"
-      ],
-      "properties": {
-        "create_schema": {
-          "description": "jsonschema (following http://json-schema.org/draft-07/schema) of the CREATE payload to be sent to handlers of this policy\n",
-          "type": "object"
-        },
-        "description": {
-          "description": "description of the policy type",
-          "type": "string"
-        },
-        "name": {
-          "description": "name of the policy type",
-          "type": "string"
-        },
-        "policy_type_id": {
-          "description": "the integer of the policy type",
-          "type": "integer"
-        }
-      },
-      "additionalProperties": false
-    }
-  },
-  "x-components": {}
-}`))
-	FlatSwaggerJSON = json.RawMessage([]byte(`{
-  "swagger": "2.0",
-  "info": {
-    "title": "RIC A1",
-    "version": "2.1.0"
-  },
-  "paths": {
-    "/A1-P/v2/healthcheck": {
-      "get": {
-        "description": "Perform a healthcheck on a1\n",
-        "tags": [
-          "A1 Mediator"
-        ],
-        "operationId": "a1.controller.get_healthcheck",
-        "responses": {
-          "200": {
-            "description": "A1 is healthy. Anything other than a 200 should be considered a1 as failing\n"
-          }
-        }
-      }
-    },
-    "/A1-P/v2/policytypes": {
+    "/A1-P/v2/policytypes/{policy_type_id}/policies/{policy_instance_id}/status/history": {
       "get": {
-        "description": "Get a list of all registered policy type ids",
-        "produces": [
-          "application/json"
-        ],
+        "description": "Retrieve the changes of the status reported by the handlers of the policy instance, oldest first\n",
         "tags": [
           "A1 Mediator"
         ],
-        "operationId": "a1.controller.get_all_policy_types",
+        "operationId": "a1.controller.get_policy_instance_status_history",
         "responses": {
           "200": {
-            "description": "list of all registered policy type ids",
+            "description": "successfully retrieved the status history\n",
             "schema": {
               "type": "array",
               "items": {
-                "$ref": "#/definitions/policy_type_id"
+                "$ref": "#/definitions/policy_status_change"
               }
-            },
-            "examples": {
-              "application/json": [
-                20000,
-                20020
-              ]
             }
           },
+          "404": {
+            "description": "there is no policy instance with this policy_instance_id or there is no policy type with this policy_type_id\n"
+          },
           "503": {
             "description": "Potentially transient backend database error. Client should attempt to retry later."
           }
         }
-      }
+      },
+      "parameters": [
+        {
+          "maximum": 2147483647,
+          "minimum": 1,
+          "type": "integer",
+          "description": "represents a policy type identifier. Currently this is restricted to an integer range.\n",
+          "name": "policy_type_id",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "represents a policy instance identifier. UUIDs are advisable but can be any string\n",
+          "name": "policy_instance_id",
+          "in": "path",
+          "required": true
+        }
+      ]
     },
-    "/A1-P/v2/policytypes/{policy_type_id}": {
+    "/A1-P/v2/policytypes/{policy_type_id}/status": {
       "get": {
-        "description": "Get this policy type\n",
+        "description": "Retrieve the status of every policy instance of this policy type in a single request\n",
         "produces": [
           "application/json"
         ],
         "tags": [
           "A1 Mediator"
         ],
-        "operationId": "a1.controller.get_policy_type",
+        "operationId": "a1.controller.get_all_instance_status_for_type",
         "responses": {
           "200": {
-            "description": "policy type successfully found",
+            "description": "successfully retrieved the status of the policy instances\n",
             "schema": {
-              "$ref": "#/definitions/policy_type_schema"
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/policy_instance_status"
+              }
             }
           },
           "404": {
-            "description": "policy type not found\n"
+            "description": "there is no policy type with this policy_type_id\n"
           },
           "503": {
             "description": "Potentially transient backend database error. Client should attempt to retry later."
           }
         }
       },
-      "put": {
-        "description": "Create a new policy type . Replace is not currently allowed; to replace, for now do a DELETE and then a PUT again.\n",
-        "consumes": [
-          "application/json"
-        ],
-        "tags": [
+      "parameters": [
+        {
+          "maximum": 2147483647,
+          "minimum": 1,
+          "type": "integer",
+          "description": "represents a policy type identifier. Currently this is restricted to an integer range.\n",
+          "name": "policy_type_id",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/A1-P/v2/resync": {
+      "post": {
+        "description": "Send every stored policy instance again to the xApps with a CREATE request, for one policy type or for all policy types\n",
+        "tags": [
+          "A1 Mediator"
+        ],
+        "operationId": "a1.controller.resync_policies",
+        "parameters": [
+          {
+            "type": "integer",
+            "description": "policy type to resynchronise, all the policy types are resynchronised when it is not given\n",
+            "name": "policyTypeId",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "202": {
+            "description": "Resynchronisation of the policy instances initiated\n"
+          },
+          "404": {
+            "description": "There is no policy type with this policyTypeId\n"
+          },
+          "503": {
+            "description": "Potentially transient backend database error. Client should attempt to retry later."
+          }
+        }
+      }
+    },
+    "/A1-P/v2/rmr/deadletters": {
+      "get": {
+        "description": "Retrieve the RMR messages that could not be sent to the xApps after all the retry attempts\n",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "A1 Mediator"
+        ],
+        "operationId": "a1.controller.get_rmr_dead_letters",
+        "responses": {
+          "200": {
+            "description": "successfully retrieved the dead letter messages\n",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/rmr_message"
+              }
+            }
+          },
+          "503": {
+            "description": "Potentially transient backend database error. Client should attempt to retry later."
+          }
+        }
+      }
+    },
+    "/A1-P/v2/status": {
+      "get": {
+        "description": "Retrieve a summary of the enforce status of the policy instances of every registered policy type\n",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "A1 Mediator"
+        ],
+        "operationId": "a1.controller.get_status_summary",
+        "responses": {
+          "200": {
+            "description": "successfully retrieved the status summary\n",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/policy_type_status_summary"
+              }
+            }
+          },
+          "503": {
+            "description": "Potentially transient backend database error. Client should attempt to retry later."
+          }
+        }
+      }
+    },
+    "/data-delivery": {
+      "post": {
+        "description": "Deliver data produced by data producer.\n",
+        "consumes": [
+          "application/json"
+        ],
+        "tags": [
+          "A1 EI Data Delivery"
+        ],
+        "operationId": "a1.controller.data_delivery",
+        "parameters": [
+          {
+            "name": "body",
+            "in": "body",
+            "schema": {
+              "description": "object to represent data object\n",
+              "type": "object"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "successfully delivered data from data producer\n"
+          },
+          "404": {
+            "description": "no job id defined for this data delivery\n"
+          }
+        }
+      }
+    }
+  },
+  "definitions": {
+    "policy_feedback": {
+      "description": "KPIs reported by an xApp on the performance of a policy instance",
+      "type": "object",
+      "properties": {
+        "handlerId": {
+          "description": "identifier of the xApp that reported the feedback",
+          "type": "string"
+        },
+        "kpis": {
+          "description": "values of the KPIs by name",
+          "type": "object",
+          "additionalProperties": {
+            "type": "number"
+          }
+        },
+        "measuredAt": {
+          "description": "time at which the xApp measured the KPIs",
+          "type": "string"
+        },
+        "ranName": {
+          "description": "RAN node the feedback was measured on",
+          "type": "string"
+        },
+        "receivedAt": {
+          "description": "time at which A1 received the feedback",
+          "type": "string"
+        }
+      }
+    },
+    "policy_handler_status": {
+      "description": "status reported by one handler of a policy instance",
+      "type": "object",
+      "properties": {
+        "detail": {
+          "description": "free text detail given by the handler",
+          "type": "string"
+        },
+        "enforceReason": {
+          "description": "reason why the handler does not enforce the policy instance",
+          "type": "string"
+        },
+        "handlerId": {
+          "description": "identifier of the xApp handling the policy instance",
+          "type": "string"
+        },
+        "ranName": {
+          "description": "RAN node the status was reported for",
+          "type": "string"
+        },
+        "status": {
+          "description": "status of the policy instance reported by the handler",
+          "type": "string"
+        },
+        "updatedAt": {
+          "description": "time at which the handler reported the status",
+          "type": "string"
+        }
+      }
+    },
+    "policy_instance_id": {
+      "description": "represents a policy instance identifier. UUIDs are advisable but can be any string\n",
+      "type": "string",
+      "example": "3d2157af-6a8f-4a7c-810f-38c2f824bf12"
+    },
+    "policy_instance_status": {
+      "description": "status of a policy instance",
+      "type": "object",
+      "properties": {
+        "aggregateStatus": {
+          "description": "ALL_OK, PARTIAL or FAILED depending on how many handlers enforce the policy instance",
+          "type": "string"
+        },
+        "createdAt": {
+          "description": "time at which the policy instance was created",
+          "type": "string"
+        },
+        "enforceDetail": {
+          "description": "detail given by the handler that does not enforce the policy instance",
+          "type": "string"
+        },
+        "enforceReason": {
+          "description": "reason why the policy instance is not enforced",
+          "type": "string"
+        },
+        "enforceStatus": {
+          "description": "ENFORCED or NOT_ENFORCED",
+          "type": "string"
+        },
+        "instanceState": {
+          "description": "lifecycle state of the policy instance",
+          "type": "string"
+        },
+        "policyInstanceId": {
+          "description": "the policy instance identifier",
+          "type": "string"
+        },
+        "scheduleStatus": {
+          "description": "PENDING or ACTIVE if the instance has a validity window",
+          "type": "string"
+        },
+        "validFrom": {
+          "description": "start of the validity window of the policy instance",
+          "type": "string"
+        },
+        "validUntil": {
+          "description": "end of the validity window of the policy instance",
+          "type": "string"
+        }
+      }
+    },
+    "policy_status_change": {
+      "description": "change of the status reported by a handler of a policy instance",
+      "type": "object",
+      "properties": {
+        "changedAt": {
+          "description": "time at which the status changed",
+          "type": "string"
+        },
+        "detail": {
+          "description": "free text detail given by the handler",
+          "type": "string"
+        },
+        "enforceReason": {
+          "description": "reason why the handler does not enforce the policy instance",
+          "type": "string"
+        },
+        "handlerId": {
+          "description": "identifier of the xApp that reported the status",
+          "type": "string"
+        },
+        "instanceStatus": {
+          "description": "status of the policy instance after the change",
+          "type": "string"
+        },
+        "previousStatus": {
+          "description": "status the handler reported before",
+          "type": "string"
+        },
+        "ranName": {
+          "description": "RAN node the status was reported for",
+          "type": "string"
+        },
+        "status": {
+          "description": "status reported by the handler",
+          "type": "string"
+        }
+      }
+    },
+    "policy_type_handler": {
+      "description": "xApp handling the policy instances of a policy type",
+      "type": "object",
+      "properties": {
+        "firstSeen": {
+          "description": "time of the first status reported by the handler",
+          "type": "string"
+        },
+        "handlerId": {
+          "description": "identifier of the xApp handling the policy type",
+          "type": "string"
+        },
+        "lastHeartbeat": {
+          "description": "time of the last heartbeat sent by the handler, the handlers sending no heartbeat have none",
+          "type": "string"
+        },
+        "lastSeen": {
+          "description": "time of the last status reported by the handler",
+          "type": "string"
+        },
+        "lastStatus": {
+          "description": "last status reported by the handler",
+          "type": "string"
+        },
+        "lost": {
+          "description": "whether the handler stopped sending heartbeats for longer than HANDLER_LIVENESS_TIMEOUT",
+          "type": "boolean"
+        }
+      }
+    },
+    "policy_type_id": {
+      "description": "represents a policy type identifier. Currently this is restricted to an integer range.\n",
+      "type": "integer",
+      "maximum": 2147483647,
+      "minimum": 1
+    },
+    "policy_type_schema": {
+      "type": "object",
+      "required": [
+        "name",
+        "description",
+        "policy_type_id",
+        "create_schema"
+      ],
+      "properties": {
+        "create_schema": {
+          "description": "jsonschema (following http://json-schema.org/draft-07/schema) of the CREATE payload to be sent to handlers of this policy\n",
+          "type": "object"
+        },
+        "description": {
+          "description": "description of the policy type",
+          "type": "string"
+        },
+        "name": {
+          "description": "name of the policy type",
+          "type": "string"
+        },
+        "policy_type_id": {
+          "description": "the integer of the policy type",
+          "type": "integer"
+        }
+      },
+      "additionalProperties": false
+    },
+    "policy_type_status_summary": {
+      "description": "enforce status summary of the instances of a policy type",
+      "type": "object",
+      "properties": {
+        "enforced": {
+          "description": "number of enforced policy instances",
+          "type": "integer"
+        },
+        "instances": {
+          "description": "number of policy instances of this policy type",
+          "type": "integer"
+        },
+        "notEnforced": {
+          "description": "number of policy instances that are not enforced",
+          "type": "integer"
+        },
+        "policyTypeId": {
+          "description": "the integer of the policy type",
+          "type": "integer"
+        }
+      }
+    },
+    "rmr_message": {
+      "description": "RMR message waiting in the outbox or given up after all retries",
+      "type": "object",
+      "properties": {
+        "attempts": {
+          "description": "number of send attempts made",
+          "type": "integer"
+        },
+        "createdAt": {
+          "description": "time at which the message was first sent",
+          "type": "string"
+        },
+        "id": {
+          "description": "identifier of the message in the outbox, policy type and instance and RAN node for policy requests",
+          "type": "string"
+        },
+        "lastAttemptAt": {
+          "description": "time of the last send attempt",
+          "type": "string"
+        },
+        "messageType": {
+          "description": "RMR message type",
+          "type": "integer"
+        },
+        "operation": {
+          "description": "operation of the policy request carried by the message",
+          "type": "string"
+        },
+        "payload": {
+          "description": "payload of the message",
+          "type": "string"
+        },
+        "policyInstanceId": {
+          "description": "policy instance of the policy request carried by the message",
+          "type": "string"
+        },
+        "policyTypeId": {
+          "description": "policy type of the policy request carried by the message",
+          "type": "integer"
+        },
+        "ranName": {
+          "description": "RAN node the message is sent to",
+          "type": "string"
+        },
+        "subId": {
+          "description": "RMR subscription id the message is sent with",
+          "type": "integer"
+        },
+        "transactionId": {
+          "description": "transaction id of the policy request carried by the message, sent as the RMR transaction id",
+          "type": "string"
+        }
+      }
+    }
+  },
+  "x-components": {}
+}`))
+	FlatSwaggerJSON = json.RawMessage([]byte(`{
+  "swagger": "2.0",
+  "info": {
+    "title": "RIC A1",
+    "version": "2.1.0"
+  },
+  "paths": {
+    "/A1-P/v2/healthcheck": {
+      "get": {
+        "description": "Perform a healthcheck on a1\n",
+        "tags": [
+          "A1 Mediator"
+        ],
+        "operationId": "a1.controller.get_healthcheck",
+        "responses": {
+          "200": {
+            "description": "A1 is healthy. Anything other than a 200 should be considered a1 as failing\n"
+          }
+        }
+      }
+    },
+    "/A1-P/v2/policytypes": {
+      "get": {
+        "description": "Get a list of all registered policy type ids",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "A1 Mediator"
+        ],
+        "operationId": "a1.controller.get_all_policy_types",
+        "responses": {
+          "200": {
+            "description": "list of all registered policy type ids",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/policy_type_id"
+              }
+            },
+            "examples": {
+              "application/json": [
+                20000,
+                20020
+              ]
+            }
+          },
+          "503": {
+            "description": "Potentially transient backend database error. Client should attempt to retry later."
+          }
+        }
+      }
+    },
+    "/A1-P/v2/policytypes/{policy_type_id}": {
+      "get": {
+        "description": "Get this policy type\n",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "A1 Mediator"
+        ],
+        "operationId": "a1.controller.get_policy_type",
+        "responses": {
+          "200": {
+            "description": "policy type successfully found",
+            "schema": {
+              "$ref": "#/definitions/policy_type_schema"
+            }
+          },
+          "404": {
+            "description": "policy type not found\n"
+          },
+          "503": {
+            "description": "Potentially transient backend database error. Client should attempt to retry later."
+          }
+        }
+      },
+      "put": {
+        "description": "Create a new policy type . Replace is not currently allowed; to replace, for now do a DELETE and then a PUT again.\n",
+        "consumes": [
+          "application/json"
+        ],
+        "tags": [
           "A1 Mediator"
         ],
         "operationId": "a1.controller.create_policy_type",
@@ -599,6 +1180,46 @@ func init() {
         }
       ]
     },
+    "/A1-P/v2/policytypes/{policy_type_id}/handlers": {
+      "get": {
+        "description": "Retrieve the xApps that reported the status of a policy instance of this policy type\n",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "A1 Mediator"
+        ],
+        "operationId": "a1.controller.get_policy_type_handlers",
+        "responses": {
+          "200": {
+            "description": "successfully retrieved the handlers of the policy type\n",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/policy_type_handler"
+              }
+            }
+          },
+          "404": {
+            "description": "there is no policy type with this policy_type_id\n"
+          },
+          "503": {
+            "description": "Potentially transient backend database error. Client should attempt to retry later."
+          }
+        }
+      },
+      "parameters": [
+        {
+          "maximum": 2147483647,
+          "minimum": 1,
+          "type": "integer",
+          "description": "represents a policy type identifier. Currently this is restricted to an integer range.\n",
+          "name": "policy_type_id",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
     "/A1-P/v2/policytypes/{policy_type_id}/policies": {
       "get": {
         "description": "get a list of all policy instance ids for this policy type id",
@@ -684,6 +1305,28 @@ func init() {
               "description": "the schema of this object is defined by the create_schema field of the policy type\n",
               "type": "object"
             }
+          },
+          {
+            "type": "string",
+            "description": "RFC 3339 timestamp from which the policy instance is in effect. The instance is only sent to the xApps once this time is reached\n",
+            "name": "validFrom",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "RFC 3339 timestamp at which the policy instance expires. The instance is deleted from the xApps once this time is reached\n",
+            "name": "validUntil",
+            "in": "query"
+          },
+          {
+            "type": "array",
+            "items": {
+              "type": "string"
+            },
+            "collectionFormat": "csv",
+            "description": "names of the RAN nodes the policy instance applies to. The instance is sent once for every node, with the node set as the RMR Meid. It is sent to every xApp when no node is given\n",
+            "name": "ranNames",
+            "in": "query"
           }
         ],
         "responses": {
@@ -696,20 +1339,237 @@ func init() {
           "404": {
             "description": "There is no policy type with this policy_type_id\n"
           },
+          "409": {
+            "description": "The policy instance is being deleted\n"
+          },
+          "503": {
+            "description": "Potentially transient backend database error. Client should attempt to retry later."
+          }
+        }
+      },
+      "delete": {
+        "description": "Delete this policy instance. The instance moves to the DELETING state and is removed once the xApps acknowledge the deletion or the delete timeout expires.\n",
+        "tags": [
+          "A1 Mediator"
+        ],
+        "operationId": "a1.controller.delete_policy_instance",
+        "parameters": [
+          {
+            "type": "boolean",
+            "description": "Remove the policy instance right away instead of waiting for the xApps to acknowledge the deletion\n",
+            "name": "force",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "202": {
+            "description": "policy instance deletion initiated\n"
+          },
+          "404": {
+            "description": "there is no policy instance with this policy_instance_id or there is no policy type with this policy_type_id\n"
+          },
+          "503": {
+            "description": "Potentially transient backend database error. Client should attempt to retry later."
+          }
+        }
+      },
+      "parameters": [
+        {
+          "maximum": 2147483647,
+          "minimum": 1,
+          "type": "integer",
+          "description": "represents a policy type identifier. Currently this is restricted to an integer range.\n",
+          "name": "policy_type_id",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "represents a policy instance identifier. UUIDs are advisable but can be any string\n",
+          "name": "policy_instance_id",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "URL send by non-RT RIC. This where non-RT RIC expects status updates on the policy creation\n",
+          "name": "notificationDestination",
+          "in": "query"
+        }
+      ]
+    },
+    "/A1-P/v2/policytypes/{policy_type_id}/policies/{policy_instance_id}/feedback": {
+      "get": {
+        "description": "Retrieve the feedback samples reported by the xApps on the performance of the policy instance, oldest first\n",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "A1 Mediator"
+        ],
+        "operationId": "a1.controller.get_policy_instance_feedback",
+        "responses": {
+          "200": {
+            "description": "successfully retrieved the policy feedback\n",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/policy_feedback"
+              }
+            }
+          },
+          "404": {
+            "description": "there is no policy instance with this policy_instance_id or there is no policy type with this policy_type_id\n"
+          },
+          "503": {
+            "description": "Potentially transient backend database error. Client should attempt to retry later."
+          }
+        }
+      },
+      "parameters": [
+        {
+          "maximum": 2147483647,
+          "minimum": 1,
+          "type": "integer",
+          "description": "represents a policy type identifier. Currently this is restricted to an integer range.\n",
+          "name": "policy_type_id",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "represents a policy instance identifier. UUIDs are advisable but can be any string\n",
+          "name": "policy_instance_id",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/A1-P/v2/policytypes/{policy_type_id}/policies/{policy_instance_id}/status": {
+      "get": {
+        "description": "Retrieve the policy instance status across all handlers of the policy If this endpoint returns successfully (200), it is either IN EFFECT or NOT IN EFFECT. IN EFFECT is returned if at least one policy handler in the RIC is implementing the policy NOT IN EFFECT is returned otherwise If a policy instance is successfully deleted, this endpoint will return a 404 (not a 200)\n",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "A1 Mediator"
+        ],
+        "operationId": "a1.controller.get_policy_instance_status",
+        "responses": {
+          "200": {
+            "description": "successfully retrieved the status\n",
+            "schema": {
+              "type": "object",
+              "properties": {
+                "ackStatus": {
+                  "type": "string",
+                  "enum": [
+                    "PENDING",
+                    "ACKNOWLEDGED",
+                    "NOT_ACKNOWLEDGED"
+                  ]
+                },
+                "aggregateStatus": {
+                  "type": "string",
+                  "enum": [
+                    "ALL_OK",
+                    "PARTIAL",
+                    "FAILED"
+                  ]
+                },
+                "enforceDetail": {
+                  "type": "string"
+                },
+                "enforceReason": {
+                  "type": "string",
+                  "enum": [
+                    "SCOPE_NOT_APPLICABLE",
+                    "STATEMENT_NOT_APPLICABLE",
+                    "OTHER_REASON"
+                  ]
+                },
+                "enforceStatus": {
+                  "type": "string",
+                  "enum": [
+                    "ENFORCED",
+                    "NOT_ENFORCED"
+                  ]
+                },
+                "handlers": {
+                  "type": "array",
+                  "items": {
+                    "$ref": "#/definitions/policy_handler_status"
+                  }
+                },
+                "instanceState": {
+                  "type": "string",
+                  "enum": [
+                    "PENDING",
+                    "ENFORCED",
+                    "NOT_ENFORCED",
+                    "DELETING",
+                    "DELETED"
+                  ]
+                },
+                "scheduleStatus": {
+                  "type": "string",
+                  "enum": [
+                    "PENDING",
+                    "ACTIVE"
+                  ]
+                },
+                "validFrom": {
+                  "type": "string"
+                },
+                "validUntil": {
+                  "type": "string"
+                }
+              }
+            }
+          },
+          "404": {
+            "description": "there is no policy instance with this policy_instance_id or there is no policy type with this policy_type_id\n"
+          },
           "503": {
             "description": "Potentially transient backend database error. Client should attempt to retry later."
           }
         }
-      },
-      "delete": {
-        "description": "Delete this policy instance\n",
+      },
+      "parameters": [
+        {
+          "maximum": 2147483647,
+          "minimum": 1,
+          "type": "integer",
+          "description": "represents a policy type identifier. Currently this is restricted to an integer range.\n",
+          "name": "policy_type_id",
+          "in": "path",
+          "required": true
+        },
+        {
+          "type": "string",
+          "description": "represents a policy instance identifier. UUIDs are advisable but can be any string\n",
+          "name": "policy_instance_id",
+          "in": "path",
+          "required": true
+        }
+      ]
+    },
+    "/A1-P/v2/policytypes/{policy_type_id}/policies/{policy_instance_id}/status/history": {
+      "get": {
+        "description": "Retrieve the changes of the status reported by the handlers of the policy instance, oldest first\n",
         "tags": [
           "A1 Mediator"
         ],
-        "operationId": "a1.controller.delete_policy_instance",
+        "operationId": "a1.controller.get_policy_instance_status_history",
         "responses": {
-          "202": {
-            "description": "policy instance deletion initiated\n"
+          "200": {
+            "description": "successfully retrieved the status history\n",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/policy_status_change"
+              }
+            }
           },
           "404": {
             "description": "there is no policy instance with this policy_instance_id or there is no policy type with this policy_type_id\n"
@@ -735,51 +1595,31 @@ func init() {
           "name": "policy_instance_id",
           "in": "path",
           "required": true
-        },
-        {
-          "type": "string",
-          "description": "URL send by non-RT RIC. This where non-RT RIC expects status updates on the policy creation\n",
-          "name": "notificationDestination",
-          "in": "query"
         }
       ]
     },
-    "/A1-P/v2/policytypes/{policy_type_id}/policies/{policy_instance_id}/status": {
+    "/A1-P/v2/policytypes/{policy_type_id}/status": {
       "get": {
-        "description": "Retrieve the policy instance status across all handlers of the policy If this endpoint returns successfully (200), it is either IN EFFECT or NOT IN EFFECT. IN EFFECT is returned if at least one policy handler in the RIC is implementing the policy NOT IN EFFECT is returned otherwise If a policy instance is successfully deleted, this endpoint will return a 404 (not a 200)\n",
+        "description": "Retrieve the status of every policy instance of this policy type in a single request\n",
         "produces": [
           "application/json"
         ],
         "tags": [
           "A1 Mediator"
         ],
-        "operationId": "a1.controller.get_policy_instance_status",
+        "operationId": "a1.controller.get_all_instance_status_for_type",
         "responses": {
           "200": {
-            "description": "successfully retrieved the status\n",
+            "description": "successfully retrieved the status of the policy instances\n",
             "schema": {
-              "type": "object",
-              "properties": {
-                "enforceReason": {
-                  "type": "string",
-                  "enum": [
-                    "SCOPE_NOT_APPLICABLE",
-                    "STATEMENT_NOT_APPLICABLE",
-                    "OTHER_REASON"
-                  ]
-                },
-                "enforceStatus": {
-                  "type": "string",
-                  "enum": [
-                    "ENFORCED",
-                    "NOT_ENFORCED"
-                  ]
-                }
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/policy_instance_status"
               }
             }
           },
           "404": {
-            "description": "there is no policy instance with this policy_instance_id or there is no policy type with this policy_type_id\n"
+            "description": "there is no policy type with this policy_type_id\n"
           },
           "503": {
             "description": "Potentially transient backend database error. Client should attempt to retry later."
@@ -795,16 +1635,89 @@ func init() {
           "name": "policy_type_id",
           "in": "path",
           "required": true
-        },
-        {
-          "type": "string",
-          "description": "represents a policy instance identifier. UUIDs are advisable but can be any string\n",
-          "name": "policy_instance_id",
-          "in": "path",
-          "required": true
         }
       ]
     },
+    "/A1-P/v2/resync": {
+      "post": {
+        "description": "Send every stored policy instance again to the xApps with a CREATE request, for one policy type or for all policy types\n",
+        "tags": [
+          "A1 Mediator"
+        ],
+        "operationId": "a1.controller.resync_policies",
+        "parameters": [
+          {
+            "type": "integer",
+            "description": "policy type to resynchronise, all the policy types are resynchronised when it is not given\n",
+            "name": "policyTypeId",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "202": {
+            "description": "Resynchronisation of the policy instances initiated\n"
+          },
+          "404": {
+            "description": "There is no policy type with this policyTypeId\n"
+          },
+          "503": {
+            "description": "Potentially transient backend database error. Client should attempt to retry later."
+          }
+        }
+      }
+    },
+    "/A1-P/v2/rmr/deadletters": {
+      "get": {
+        "description": "Retrieve the RMR messages that could not be sent to the xApps after all the retry attempts\n",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "A1 Mediator"
+        ],
+        "operationId": "a1.controller.get_rmr_dead_letters",
+        "responses": {
+          "200": {
+            "description": "successfully retrieved the dead letter messages\n",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/rmr_message"
+              }
+            }
+          },
+          "503": {
+            "description": "Potentially transient backend database error. Client should attempt to retry later."
+          }
+        }
+      }
+    },
+    "/A1-P/v2/status": {
+      "get": {
+        "description": "Retrieve a summary of the enforce status of the policy instances of every registered policy type\n",
+        "produces": [
+          "application/json"
+        ],
+        "tags": [
+          "A1 Mediator"
+        ],
+        "operationId": "a1.controller.get_status_summary",
+        "responses": {
+          "200": {
+            "description": "successfully retrieved the status summary\n",
+            "schema": {
+              "type": "array",
+              "items": {
+                "$ref": "#/definitions/policy_type_status_summary"
+              }
+            }
+          },
+          "503": {
+            "description": "Potentially transient backend database error. Client should attempt to retry later."
+          }
+        }
+      }
+    },
     "/data-delivery": {
       "post": {
         "description": "Deliver data produced by data producer.\n",
@@ -837,11 +1750,184 @@ func init() {
     }
   },
   "definitions": {
+    "policy_feedback": {
+      "description": "KPIs reported by an xApp on the performance of a policy instance",
+      "type": "object",
+      "properties": {
+        "handlerId": {
+          "description": "identifier of the xApp that reported the feedback",
+          "type": "string"
+        },
+        "kpis": {
+          "description": "values of the KPIs by name",
+          "type": "object",
+          "additionalProperties": {
+            "type": "number"
+          }
+        },
+        "measuredAt": {
+          "description": "time at which the xApp measured the KPIs",
+          "type": "string"
+        },
+        "ranName": {
+          "description": "RAN node the feedback was measured on",
+          "type": "string"
+        },
+        "receivedAt": {
+          "description": "time at which A1 received the feedback",
+          "type": "string"
+        }
+      }
+    },
+    "policy_handler_status": {
+      "description": "status reported by one handler of a policy instance",
+      "type": "object",
+      "properties": {
+        "detail": {
+          "description": "free text detail given by the handler",
+          "type": "string"
+        },
+        "enforceReason": {
+          "description": "reason why the handler does not enforce the policy instance",
+          "type": "string"
+        },
+        "handlerId": {
+          "description": "identifier of the xApp handling the policy instance",
+          "type": "string"
+        },
+        "ranName": {
+          "description": "RAN node the status was reported for",
+          "type": "string"
+        },
+        "status": {
+          "description": "status of the policy instance reported by the handler",
+          "type": "string"
+        },
+        "updatedAt": {
+          "description": "time at which the handler reported the status",
+          "type": "string"
+        }
+      }
+    },
     "policy_instance_id": {
       "description": "represents a policy instance identifier. UUIDs are advisable but can be any string\n",
       "type": "string",
       "example": "3d2157af-6a8f-4a7c-810f-38c2f824bf12"
     },
+    "policy_instance_status": {
+      "description": "status of a policy instance",
+      "type": "object",
+      "properties": {
+        "aggregateStatus": {
+          "description": "ALL_OK, PARTIAL or FAILED depending on how many handlers enforce the policy instance",
+          "type": "string"
+        },
+        "createdAt": {
+          "description": "time at which the policy instance was created",
+          "type": "string"
+        },
+        "enforceDetail": {
+          "description": "detail given by the handler that does not enforce the policy instance",
+          "type": "string"
+        },
+        "enforceReason": {
+          "description": "reason why the policy instance is not enforced",
+          "type": "string"
+        },
+        "enforceStatus": {
+          "description": "ENFORCED or NOT_ENFORCED",
+          "type": "string"
+        },
+        "instanceState": {
+          "description": "lifecycle state of the policy instance",
+          "type": "string"
+        },
+        "policyInstanceId": {
+          "description": "the policy instance identifier",
+          "type": "string"
+        },
+        "scheduleStatus": {
+          "description": "PENDING or ACTIVE if the instance has a validity window",
+          "type": "string"
+        },
+        "validFrom": {
+          "description": "start of the validity window of the policy instance",
+          "type": "string"
+        },
+        "validUntil": {
+          "description": "end of the validity window of the policy instance",
+          "type": "string"
+        }
+      }
+    },
+    "policy_status_change": {
+      "description": "change of the status reported by a handler of a policy instance",
+      "type": "object",
+      "properties": {
+        "changedAt": {
+          "description": "time at which the status changed",
+          "type": "string"
+        },
+        "detail": {
+          "description": "free text detail given by the handler",
+          "type": "string"
+        },
+        "enforceReason": {
+          "description": "reason why the handler does not enforce the policy instance",
+          "type": "string"
+        },
+        "handlerId": {
+          "description": "identifier of the xApp that reported the status",
+          "type": "string"
+        },
+        "instanceStatus": {
+          "description": "status of the policy instance after the change",
+          "type": "string"
+        },
+        "previousStatus": {
+          "description": "status the handler reported before",
+          "type": "string"
+        },
+        "ranName": {
+          "description": "RAN node the status was reported for",
+          "type": "string"
+        },
+        "status": {
+          "description": "status reported by the handler",
+          "type": "string"
+        }
+      }
+    },
+    "policy_type_handler": {
+      "description": "xApp handling the policy instances of a policy type",
+      "type": "object",
+      "properties": {
+        "firstSeen": {
+          "description": "time of the first status reported by the handler",
+          "type": "string"
+        },
+        "handlerId": {
+          "description": "identifier of the xApp handling the policy type",
+          "type": "string"
+        },
+        "lastHeartbeat": {
+          "description": "time of the last heartbeat sent by the handler, the handlers sending no heartbeat have none",
+          "type": "string"
+        },
+        "lastSeen": {
+          "description": "time of the last status reported by the handler",
+          "type": "string"
+        },
+        "lastStatus": {
+          "description": "last status reported by the handler",
+          "type": "string"
+        },
+        "lost": {
+          "description": "whether the handler stopped sending heartbeats for longer than HANDLER_LIVENESS_TIMEOUT",
+          "type": "boolean"
+        }
+      }
+    },
     "policy_type_id": {
       "description": "represents a policy type identifier. Currently this is restricted to an integer range.\n",
       "type": "integer",
@@ -875,6 +1961,82 @@ func init() {
         }
       },
       "additionalProperties": false
+    },
+    "policy_type_status_summary": {
+      "description": "enforce status summary of the instances of a policy type",
+      "type": "object",
+      "properties": {
+        "enforced": {
+          "description": "number of enforced policy instances",
+          "type": "integer"
+        },
+        "instances": {
+          "description": "number of policy instances of this policy type",
+          "type": "integer"
+        },
+        "notEnforced": {
+          "description": "number of policy instances that are not enforced",
+          "type": "integer"
+        },
+        "policyTypeId": {
+          "description": "the integer of the policy type",
+          "type": "integer"
+        }
+      }
+    },
+    "rmr_message": {
+      "description": "RMR message waiting in the outbox or given up after all retries",
+      "type": "object",
+      "properties": {
+        "attempts": {
+          "description": "number of send attempts made",
+          "type": "integer"
+        },
+        "createdAt": {
+          "description": "time at which the message was first sent",
+          "type": "string"
+        },
+        "id": {
+          "description": "identifier of the message in the outbox, policy type and instance and RAN node for policy requests",
+          "type": "string"
+        },
+        "lastAttemptAt": {
+          "description": "time of the last send attempt",
+          "type": "string"
+        },
+        "messageType": {
+          "description": "RMR message type",
+          "type": "integer"
+        },
+        "operation": {
+          "description": "operation of the policy request carried by the message",
+          "type": "string"
+        },
+        "payload": {
+          "description": "payload of the message",
+          "type": "string"
+        },
+        "policyInstanceId": {
+          "description": "policy instance of the policy request carried by the message",
+          "type": "string"
+        },
+        "policyTypeId": {
+          "description": "policy type of the policy request carried by the message",
+          "type": "integer"
+        },
+        "ranName": {
+          "description": "RAN node the message is sent to",
+          "type": "string"
+        },
+        "subId": {
+          "description": "RMR subscription id the message is sent with",
+          "type": "integer"
+        },
+        "transactionId": {
+          "description": "transaction id of the policy request carried by the message, sent as the RMR transaction id",
+          "type": "string"
+        }
+      }
     }
   },
   "x-components": {}
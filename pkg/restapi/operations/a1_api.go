@@ -80,6 +80,9 @@ func NewA1API(spec *loads.Document) *A1API {
 		A1MediatorA1ControllerDeletePolicyTypeHandler: a1_mediator.A1ControllerDeletePolicyTypeHandlerFunc(func(params a1_mediator.A1ControllerDeletePolicyTypeParams) middleware.Responder {
 			return middleware.NotImplemented("operation a1_mediator.A1ControllerDeletePolicyType has not yet been implemented")
 		}),
+		A1MediatorA1ControllerGetAllInstanceStatusForTypeHandler: a1_mediator.A1ControllerGetAllInstanceStatusForTypeHandlerFunc(func(params a1_mediator.A1ControllerGetAllInstanceStatusForTypeParams) middleware.Responder {
+			return middleware.NotImplemented("operation a1_mediator.A1ControllerGetAllInstanceStatusForType has not yet been implemented")
+		}),
 		A1MediatorA1ControllerGetAllInstancesForTypeHandler: a1_mediator.A1ControllerGetAllInstancesForTypeHandlerFunc(func(params a1_mediator.A1ControllerGetAllInstancesForTypeParams) middleware.Responder {
 			return middleware.NotImplemented("operation a1_mediator.A1ControllerGetAllInstancesForType has not yet been implemented")
 		}),
@@ -92,12 +95,30 @@ func NewA1API(spec *loads.Document) *A1API {
 		A1MediatorA1ControllerGetPolicyInstanceHandler: a1_mediator.A1ControllerGetPolicyInstanceHandlerFunc(func(params a1_mediator.A1ControllerGetPolicyInstanceParams) middleware.Responder {
 			return middleware.NotImplemented("operation a1_mediator.A1ControllerGetPolicyInstance has not yet been implemented")
 		}),
+		A1MediatorA1ControllerGetPolicyInstanceFeedbackHandler: a1_mediator.A1ControllerGetPolicyInstanceFeedbackHandlerFunc(func(params a1_mediator.A1ControllerGetPolicyInstanceFeedbackParams) middleware.Responder {
+			return middleware.NotImplemented("operation a1_mediator.A1ControllerGetPolicyInstanceFeedback has not yet been implemented")
+		}),
 		A1MediatorA1ControllerGetPolicyInstanceStatusHandler: a1_mediator.A1ControllerGetPolicyInstanceStatusHandlerFunc(func(params a1_mediator.A1ControllerGetPolicyInstanceStatusParams) middleware.Responder {
 			return middleware.NotImplemented("operation a1_mediator.A1ControllerGetPolicyInstanceStatus has not yet been implemented")
 		}),
+		A1MediatorA1ControllerGetPolicyInstanceStatusHistoryHandler: a1_mediator.A1ControllerGetPolicyInstanceStatusHistoryHandlerFunc(func(params a1_mediator.A1ControllerGetPolicyInstanceStatusHistoryParams) middleware.Responder {
+			return middleware.NotImplemented("operation a1_mediator.A1ControllerGetPolicyInstanceStatusHistory has not yet been implemented")
+		}),
 		A1MediatorA1ControllerGetPolicyTypeHandler: a1_mediator.A1ControllerGetPolicyTypeHandlerFunc(func(params a1_mediator.A1ControllerGetPolicyTypeParams) middleware.Responder {
 			return middleware.NotImplemented("operation a1_mediator.A1ControllerGetPolicyType has not yet been implemented")
 		}),
+		A1MediatorA1ControllerGetPolicyTypeHandlersHandler: a1_mediator.A1ControllerGetPolicyTypeHandlersHandlerFunc(func(params a1_mediator.A1ControllerGetPolicyTypeHandlersParams) middleware.Responder {
+			return middleware.NotImplemented("operation a1_mediator.A1ControllerGetPolicyTypeHandlers has not yet been implemented")
+		}),
+		A1MediatorA1ControllerGetRmrDeadLettersHandler: a1_mediator.A1ControllerGetRmrDeadLettersHandlerFunc(func(params a1_mediator.A1ControllerGetRmrDeadLettersParams) middleware.Responder {
+			return middleware.NotImplemented("operation a1_mediator.A1ControllerGetRmrDeadLetters has not yet been implemented")
+		}),
+		A1MediatorA1ControllerGetStatusSummaryHandler: a1_mediator.A1ControllerGetStatusSummaryHandlerFunc(func(params a1_mediator.A1ControllerGetStatusSummaryParams) middleware.Responder {
+			return middleware.NotImplemented("operation a1_mediator.A1ControllerGetStatusSummary has not yet been implemented")
+		}),
+		A1MediatorA1ControllerResyncPoliciesHandler: a1_mediator.A1ControllerResyncPoliciesHandlerFunc(func(params a1_mediator.A1ControllerResyncPoliciesParams) middleware.Responder {
+			return middleware.NotImplemented("operation a1_mediator.A1ControllerResyncPolicies has not yet been implemented")
+		}),
 	}
 }
 
@@ -144,6 +165,8 @@ type A1API struct {
 	A1MediatorA1ControllerDeletePolicyInstanceHandler a1_mediator.A1ControllerDeletePolicyInstanceHandler
 	// A1MediatorA1ControllerDeletePolicyTypeHandler sets the operation handler for the a1 controller delete policy type operation
 	A1MediatorA1ControllerDeletePolicyTypeHandler a1_mediator.A1ControllerDeletePolicyTypeHandler
+	// A1MediatorA1ControllerGetAllInstanceStatusForTypeHandler sets the operation handler for the a1 controller get all instance status for type operation
+	A1MediatorA1ControllerGetAllInstanceStatusForTypeHandler a1_mediator.A1ControllerGetAllInstanceStatusForTypeHandler
 	// A1MediatorA1ControllerGetAllInstancesForTypeHandler sets the operation handler for the a1 controller get all instances for type operation
 	A1MediatorA1ControllerGetAllInstancesForTypeHandler a1_mediator.A1ControllerGetAllInstancesForTypeHandler
 	// A1MediatorA1ControllerGetAllPolicyTypesHandler sets the operation handler for the a1 controller get all policy types operation
@@ -152,10 +175,22 @@ type A1API struct {
 	A1MediatorA1ControllerGetHealthcheckHandler a1_mediator.A1ControllerGetHealthcheckHandler
 	// A1MediatorA1ControllerGetPolicyInstanceHandler sets the operation handler for the a1 controller get policy instance operation
 	A1MediatorA1ControllerGetPolicyInstanceHandler a1_mediator.A1ControllerGetPolicyInstanceHandler
+	// A1MediatorA1ControllerGetPolicyInstanceFeedbackHandler sets the operation handler for the a1 controller get policy instance feedback operation
+	A1MediatorA1ControllerGetPolicyInstanceFeedbackHandler a1_mediator.A1ControllerGetPolicyInstanceFeedbackHandler
 	// A1MediatorA1ControllerGetPolicyInstanceStatusHandler sets the operation handler for the a1 controller get policy instance status operation
 	A1MediatorA1ControllerGetPolicyInstanceStatusHandler a1_mediator.A1ControllerGetPolicyInstanceStatusHandler
+	// A1MediatorA1ControllerGetPolicyInstanceStatusHistoryHandler sets the operation handler for the a1 controller get policy instance status history operation
+	A1MediatorA1ControllerGetPolicyInstanceStatusHistoryHandler a1_mediator.A1ControllerGetPolicyInstanceStatusHistoryHandler
 	// A1MediatorA1ControllerGetPolicyTypeHandler sets the operation handler for the a1 controller get policy type operation
 	A1MediatorA1ControllerGetPolicyTypeHandler a1_mediator.A1ControllerGetPolicyTypeHandler
+	// A1MediatorA1ControllerGetPolicyTypeHandlersHandler sets the operation handler for the a1 controller get policy type handlers operation
+	A1MediatorA1ControllerGetPolicyTypeHandlersHandler a1_mediator.A1ControllerGetPolicyTypeHandlersHandler
+	// A1MediatorA1ControllerGetRmrDeadLettersHandler sets the operation handler for the a1 controller get rmr dead letters operation
+	A1MediatorA1ControllerGetRmrDeadLettersHandler a1_mediator.A1ControllerGetRmrDeadLettersHandler
+	// A1MediatorA1ControllerGetStatusSummaryHandler sets the operation handler for the a1 controller get status summary operation
+	A1MediatorA1ControllerGetStatusSummaryHandler a1_mediator.A1ControllerGetStatusSummaryHandler
+	// A1MediatorA1ControllerResyncPoliciesHandler sets the operation handler for the a1 controller resync policies operation
+	A1MediatorA1ControllerResyncPoliciesHandler a1_mediator.A1ControllerResyncPoliciesHandler
 
 	// ServeError is called when an error is received, there is a default handler
 	// but you can set your own with this
@@ -248,6 +283,9 @@ func (o *A1API) Validate() error {
 	if o.A1MediatorA1ControllerDeletePolicyTypeHandler == nil {
 		unregistered = append(unregistered, "a1_mediator.A1ControllerDeletePolicyTypeHandler")
 	}
+	if o.A1MediatorA1ControllerGetAllInstanceStatusForTypeHandler == nil {
+		unregistered = append(unregistered, "a1_mediator.A1ControllerGetAllInstanceStatusForTypeHandler")
+	}
 	if o.A1MediatorA1ControllerGetAllInstancesForTypeHandler == nil {
 		unregistered = append(unregistered, "a1_mediator.A1ControllerGetAllInstancesForTypeHandler")
 	}
@@ -260,12 +298,30 @@ func (o *A1API) Validate() error {
 	if o.A1MediatorA1ControllerGetPolicyInstanceHandler == nil {
 		unregistered = append(unregistered, "a1_mediator.A1ControllerGetPolicyInstanceHandler")
 	}
+	if o.A1MediatorA1ControllerGetPolicyInstanceFeedbackHandler == nil {
+		unregistered = append(unregistered, "a1_mediator.A1ControllerGetPolicyInstanceFeedbackHandler")
+	}
 	if o.A1MediatorA1ControllerGetPolicyInstanceStatusHandler == nil {
 		unregistered = append(unregistered, "a1_mediator.A1ControllerGetPolicyInstanceStatusHandler")
 	}
+	if o.A1MediatorA1ControllerGetPolicyInstanceStatusHistoryHandler == nil {
+		unregistered = append(unregistered, "a1_mediator.A1ControllerGetPolicyInstanceStatusHistoryHandler")
+	}
 	if o.A1MediatorA1ControllerGetPolicyTypeHandler == nil {
 		unregistered = append(unregistered, "a1_mediator.A1ControllerGetPolicyTypeHandler")
 	}
+	if o.A1MediatorA1ControllerGetPolicyTypeHandlersHandler == nil {
+		unregistered = append(unregistered, "a1_mediator.A1ControllerGetPolicyTypeHandlersHandler")
+	}
+	if o.A1MediatorA1ControllerGetRmrDeadLettersHandler == nil {
+		unregistered = append(unregistered, "a1_mediator.A1ControllerGetRmrDeadLettersHandler")
+	}
+	if o.A1MediatorA1ControllerGetStatusSummaryHandler == nil {
+		unregistered = append(unregistered, "a1_mediator.A1ControllerGetStatusSummaryHandler")
+	}
+	if o.A1MediatorA1ControllerResyncPoliciesHandler == nil {
+		unregistered = append(unregistered, "a1_mediator.A1ControllerResyncPoliciesHandler")
+	}
 
 	if len(unregistered) > 0 {
 		return fmt.Errorf("missing registration: %s", strings.Join(unregistered, ", "))
@@ -377,6 +433,10 @@ func (o *A1API) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/A1-P/v2/policytypes/{policy_type_id}/status"] = a1_mediator.NewA1ControllerGetAllInstanceStatusForType(o.context, o.A1MediatorA1ControllerGetAllInstanceStatusForTypeHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/A1-P/v2/policytypes/{policy_type_id}/policies"] = a1_mediator.NewA1ControllerGetAllInstancesForType(o.context, o.A1MediatorA1ControllerGetAllInstancesForTypeHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
@@ -393,11 +453,35 @@ func (o *A1API) initHandlerCache() {
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/A1-P/v2/policytypes/{policy_type_id}/policies/{policy_instance_id}/feedback"] = a1_mediator.NewA1ControllerGetPolicyInstanceFeedback(o.context, o.A1MediatorA1ControllerGetPolicyInstanceFeedbackHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/A1-P/v2/policytypes/{policy_type_id}/policies/{policy_instance_id}/status"] = a1_mediator.NewA1ControllerGetPolicyInstanceStatus(o.context, o.A1MediatorA1ControllerGetPolicyInstanceStatusHandler)
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
+	o.handlers["GET"]["/A1-P/v2/policytypes/{policy_type_id}/policies/{policy_instance_id}/status/history"] = a1_mediator.NewA1ControllerGetPolicyInstanceStatusHistory(o.context, o.A1MediatorA1ControllerGetPolicyInstanceStatusHistoryHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
 	o.handlers["GET"]["/A1-P/v2/policytypes/{policy_type_id}"] = a1_mediator.NewA1ControllerGetPolicyType(o.context, o.A1MediatorA1ControllerGetPolicyTypeHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/A1-P/v2/policytypes/{policy_type_id}/handlers"] = a1_mediator.NewA1ControllerGetPolicyTypeHandlers(o.context, o.A1MediatorA1ControllerGetPolicyTypeHandlersHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/A1-P/v2/rmr/deadletters"] = a1_mediator.NewA1ControllerGetRmrDeadLetters(o.context, o.A1MediatorA1ControllerGetRmrDeadLettersHandler)
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/A1-P/v2/status"] = a1_mediator.NewA1ControllerGetStatusSummary(o.context, o.A1MediatorA1ControllerGetStatusSummaryHandler)
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/A1-P/v2/resync"] = a1_mediator.NewA1ControllerResyncPolicies(o.context, o.A1MediatorA1ControllerResyncPoliciesHandler)
 }
 
 // Serve creates a http handler to serve the API over HTTP
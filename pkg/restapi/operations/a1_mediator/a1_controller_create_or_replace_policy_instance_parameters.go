@@ -76,6 +76,22 @@ type A1ControllerCreateOrReplacePolicyInstanceParams struct {
 	  In: path
 	*/
 	PolicyTypeID int64
+	/*names of the RAN nodes the policy instance applies to. The instance is sent once for every node, with the node set as the RMR Meid. It is sent to every xApp when no node is given
+
+	  In: query
+	  Collection Format: csv
+	*/
+	RanNames []string
+	/*RFC 3339 timestamp from which the policy instance is in effect. The instance is only sent to the xApps once this time is reached
+
+	  In: query
+	*/
+	ValidFrom *string
+	/*RFC 3339 timestamp at which the policy instance expires. The instance is deleted from the xApps once this time is reached
+
+	  In: query
+	*/
+	ValidUntil *string
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -114,6 +130,21 @@ func (o *A1ControllerCreateOrReplacePolicyInstanceParams) BindRequest(r *http.Re
 	if err := o.bindPolicyTypeID(rPolicyTypeID, rhkPolicyTypeID, route.Formats); err != nil {
 		res = append(res, err)
 	}
+
+	qRanNames, qhkRanNames, _ := qs.GetOK("ranNames")
+	if err := o.bindRanNames(qRanNames, qhkRanNames, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qValidFrom, qhkValidFrom, _ := qs.GetOK("validFrom")
+	if err := o.bindValidFrom(qValidFrom, qhkValidFrom, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
+	qValidUntil, qhkValidUntil, _ := qs.GetOK("validUntil")
+	if err := o.bindValidUntil(qValidUntil, qhkValidUntil, route.Formats); err != nil {
+		res = append(res, err)
+	}
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
@@ -188,3 +219,66 @@ func (o *A1ControllerCreateOrReplacePolicyInstanceParams) validatePolicyTypeID(f
 
 	return nil
 }
+
+// bindRanNames binds and validates array parameter RanNames from query.
+//
+// Arrays are parsed according to CollectionFormat: "csv" (defaults to "csv" when empty).
+func (o *A1ControllerCreateOrReplacePolicyInstanceParams) bindRanNames(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var qvRanNames string
+	if len(rawData) > 0 {
+		qvRanNames = rawData[len(rawData)-1]
+	}
+
+	// CollectionFormat: csv
+	ranNamesIC := swag.SplitByFormat(qvRanNames, "csv")
+	if len(ranNamesIC) == 0 {
+		return nil
+	}
+
+	var ranNamesIR []string
+	for _, ranNamesIV := range ranNamesIC {
+		ranNamesI := ranNamesIV
+
+		ranNamesIR = append(ranNamesIR, ranNamesI)
+	}
+
+	o.RanNames = ranNamesIR
+
+	return nil
+}
+
+// bindValidFrom binds and validates parameter ValidFrom from query.
+func (o *A1ControllerCreateOrReplacePolicyInstanceParams) bindValidFrom(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.ValidFrom = &raw
+
+	return nil
+}
+
+// bindValidUntil binds and validates parameter ValidUntil from query.
+func (o *A1ControllerCreateOrReplacePolicyInstanceParams) bindValidUntil(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+	o.ValidUntil = &raw
+
+	return nil
+}
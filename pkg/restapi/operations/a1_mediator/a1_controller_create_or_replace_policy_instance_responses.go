@@ -106,6 +106,31 @@ func (o *A1ControllerCreateOrReplacePolicyInstanceNotFound) WriteResponse(rw htt
 	rw.WriteHeader(404)
 }
 
+// A1ControllerCreateOrReplacePolicyInstanceConflictCode is the HTTP code returned for type A1ControllerCreateOrReplacePolicyInstanceConflict
+const A1ControllerCreateOrReplacePolicyInstanceConflictCode int = 409
+
+/*A1ControllerCreateOrReplacePolicyInstanceConflict The policy instance is being deleted
+
+
+swagger:response a1ControllerCreateOrReplacePolicyInstanceConflict
+*/
+type A1ControllerCreateOrReplacePolicyInstanceConflict struct {
+}
+
+// NewA1ControllerCreateOrReplacePolicyInstanceConflict creates A1ControllerCreateOrReplacePolicyInstanceConflict with default headers values
+func NewA1ControllerCreateOrReplacePolicyInstanceConflict() *A1ControllerCreateOrReplacePolicyInstanceConflict {
+
+	return &A1ControllerCreateOrReplacePolicyInstanceConflict{}
+}
+
+// WriteResponse to the client
+func (o *A1ControllerCreateOrReplacePolicyInstanceConflict) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(409)
+}
+
 // A1ControllerCreateOrReplacePolicyInstanceServiceUnavailableCode is the HTTP code returned for type A1ControllerCreateOrReplacePolicyInstanceServiceUnavailable
 const A1ControllerCreateOrReplacePolicyInstanceServiceUnavailableCode int = 503
 
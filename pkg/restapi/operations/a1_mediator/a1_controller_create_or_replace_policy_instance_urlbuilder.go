@@ -40,6 +40,9 @@ type A1ControllerCreateOrReplacePolicyInstanceURL struct {
 	PolicyTypeID     int64
 
 	NotificationDestination *string
+	RanNames                []string
+	ValidFrom               *string
+	ValidUntil              *string
 
 	_basePath string
 	// avoid unkeyed usage
@@ -94,6 +97,39 @@ func (o *A1ControllerCreateOrReplacePolicyInstanceURL) Build() (*url.URL, error)
 		qs.Set("notificationDestination", notificationDestinationQ)
 	}
 
+	var ranNamesIR []string
+	for _, ranNamesI := range o.RanNames {
+		ranNamesIS := ranNamesI
+		if ranNamesIS != "" {
+			ranNamesIR = append(ranNamesIR, ranNamesIS)
+		}
+	}
+
+	ranNames := swag.JoinByFormat(ranNamesIR, "csv")
+
+	if len(ranNames) > 0 {
+		qsv := ranNames[0]
+		if qsv != "" {
+			qs.Set("ranNames", qsv)
+		}
+	}
+
+	var validFromQ string
+	if o.ValidFrom != nil {
+		validFromQ = *o.ValidFrom
+	}
+	if validFromQ != "" {
+		qs.Set("validFrom", validFromQ)
+	}
+
+	var validUntilQ string
+	if o.ValidUntil != nil {
+		validUntilQ = *o.ValidUntil
+	}
+	if validUntilQ != "" {
+		qs.Set("validUntil", validUntilQ)
+	}
+
 	_result.RawQuery = qs.Encode()
 
 	return &_result, nil
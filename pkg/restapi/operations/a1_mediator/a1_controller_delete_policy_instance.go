@@ -51,7 +51,7 @@ func NewA1ControllerDeletePolicyInstance(ctx *middleware.Context, handler A1Cont
 
 /* A1ControllerDeletePolicyInstance swagger:route DELETE /A1-P/v2/policytypes/{policy_type_id}/policies/{policy_instance_id} A1 Mediator a1ControllerDeletePolicyInstance
 
-Delete this policy instance
+Delete this policy instance. The instance moves to the DELETING state and is removed once the xApps acknowledge the deletion or the delete timeout expires.
 
 
 */
@@ -53,6 +53,11 @@ type A1ControllerDeletePolicyInstanceParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*Remove the policy instance right away instead of waiting for the xApps to acknowledge the deletion
+
+	  In: query
+	*/
+	Force *bool
 	/*URL send by non-RT RIC. This where non-RT RIC expects status updates on the policy creation
 
 	  In: query
@@ -85,6 +90,11 @@ func (o *A1ControllerDeletePolicyInstanceParams) BindRequest(r *http.Request, ro
 
 	qs := runtime.Values(r.URL.Query())
 
+	qForce, qhkForce, _ := qs.GetOK("force")
+	if err := o.bindForce(qForce, qhkForce, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qNotificationDestination, qhkNotificationDestination, _ := qs.GetOK("notificationDestination")
 	if err := o.bindNotificationDestination(qNotificationDestination, qhkNotificationDestination, route.Formats); err != nil {
 		res = append(res, err)
@@ -105,6 +115,29 @@ func (o *A1ControllerDeletePolicyInstanceParams) BindRequest(r *http.Request, ro
 	return nil
 }
 
+// bindForce binds and validates parameter Force from query.
+func (o *A1ControllerDeletePolicyInstanceParams) bindForce(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+
+	if raw == "" { // empty values pass all other validations
+		return nil
+	}
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("force", "query", "bool", raw)
+	}
+	o.Force = &value
+
+	return nil
+}
+
 // bindNotificationDestination binds and validates parameter NotificationDestination from query.
 func (o *A1ControllerDeletePolicyInstanceParams) bindNotificationDestination(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	var raw string
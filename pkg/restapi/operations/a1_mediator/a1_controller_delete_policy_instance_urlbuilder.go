@@ -39,6 +39,7 @@ type A1ControllerDeletePolicyInstanceURL struct {
 	PolicyInstanceID string
 	PolicyTypeID     int64
 
+	Force                   *bool
 	NotificationDestination *string
 
 	_basePath string
@@ -86,6 +87,14 @@ func (o *A1ControllerDeletePolicyInstanceURL) Build() (*url.URL, error) {
 
 	qs := make(url.Values)
 
+	var forceQ string
+	if o.Force != nil {
+		forceQ = swag.FormatBool(*o.Force)
+	}
+	if forceQ != "" {
+		qs.Set("force", forceQ)
+	}
+
 	var notificationDestinationQ string
 	if o.NotificationDestination != nil {
 		notificationDestinationQ = *o.NotificationDestination
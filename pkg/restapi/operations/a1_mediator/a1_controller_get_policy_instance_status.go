@@ -29,12 +29,15 @@
 	"context"
 	"encoding/json"
 	"net/http"
+	"strconv"
 
 	"github.com/go-openapi/errors"
 	"github.com/go-openapi/runtime/middleware"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 	"github.com/go-openapi/validate"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/models"
 )
 
 // A1ControllerGetPolicyInstanceStatusHandlerFunc turns a function with the right signature into a a1 controller get policy instance status handler
@@ -87,6 +90,17 @@ func (o *A1ControllerGetPolicyInstanceStatus) ServeHTTP(rw http.ResponseWriter,
 // swagger:model A1ControllerGetPolicyInstanceStatusOKBody
 type A1ControllerGetPolicyInstanceStatusOKBody struct {
 
+	// ack status
+	// Enum: [PENDING ACKNOWLEDGED NOT_ACKNOWLEDGED]
+	AckStatus string `json:"ackStatus,omitempty"`
+
+	// aggregate status
+	// Enum: [ALL_OK PARTIAL FAILED]
+	AggregateStatus string `json:"aggregateStatus,omitempty"`
+
+	// enforce detail
+	EnforceDetail string `json:"enforceDetail,omitempty"`
+
 	// enforce reason
 	// Enum: [SCOPE_NOT_APPLICABLE STATEMENT_NOT_APPLICABLE OTHER_REASON]
 	EnforceReason string `json:"enforceReason,omitempty"`
@@ -94,12 +108,37 @@ type A1ControllerGetPolicyInstanceStatusOKBody struct {
 	// enforce status
 	// Enum: [ENFORCED NOT_ENFORCED]
 	EnforceStatus string `json:"enforceStatus,omitempty"`
+
+	// handlers
+	Handlers []*models.PolicyHandlerStatus `json:"handlers"`
+
+	// instance state
+	// Enum: [PENDING ENFORCED NOT_ENFORCED DELETING DELETED]
+	InstanceState string `json:"instanceState,omitempty"`
+
+	// schedule status
+	// Enum: [PENDING ACTIVE]
+	ScheduleStatus string `json:"scheduleStatus,omitempty"`
+
+	// valid from
+	ValidFrom string `json:"validFrom,omitempty"`
+
+	// valid until
+	ValidUntil string `json:"validUntil,omitempty"`
 }
 
 // Validate validates this a1 controller get policy instance status o k body
 func (o *A1ControllerGetPolicyInstanceStatusOKBody) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := o.validateAckStatus(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := o.validateAggregateStatus(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := o.validateEnforceReason(formats); err != nil {
 		res = append(res, err)
 	}
@@ -108,12 +147,114 @@ func (o *A1ControllerGetPolicyInstanceStatusOKBody) Validate(formats strfmt.Regi
 		res = append(res, err)
 	}
 
+	if err := o.validateHandlers(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := o.validateInstanceState(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := o.validateScheduleStatus(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
 
+var a1ControllerGetPolicyInstanceStatusOKBodyTypeAckStatusPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["PENDING","ACKNOWLEDGED","NOT_ACKNOWLEDGED"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		a1ControllerGetPolicyInstanceStatusOKBodyTypeAckStatusPropEnum = append(a1ControllerGetPolicyInstanceStatusOKBodyTypeAckStatusPropEnum, v)
+	}
+}
+
+const (
+
+	// A1ControllerGetPolicyInstanceStatusOKBodyAckStatusPENDING captures enum value "PENDING"
+	A1ControllerGetPolicyInstanceStatusOKBodyAckStatusPENDING string = "PENDING"
+
+	// A1ControllerGetPolicyInstanceStatusOKBodyAckStatusACKNOWLEDGED captures enum value "ACKNOWLEDGED"
+	A1ControllerGetPolicyInstanceStatusOKBodyAckStatusACKNOWLEDGED string = "ACKNOWLEDGED"
+
+	// A1ControllerGetPolicyInstanceStatusOKBodyAckStatusNOTACKNOWLEDGED captures enum value "NOT_ACKNOWLEDGED"
+	A1ControllerGetPolicyInstanceStatusOKBodyAckStatusNOTACKNOWLEDGED string = "NOT_ACKNOWLEDGED"
+)
+
+// prop value enum
+func (o *A1ControllerGetPolicyInstanceStatusOKBody) validateAckStatusEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, a1ControllerGetPolicyInstanceStatusOKBodyTypeAckStatusPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (o *A1ControllerGetPolicyInstanceStatusOKBody) validateAckStatus(formats strfmt.Registry) error {
+	if swag.IsZero(o.AckStatus) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := o.validateAckStatusEnum("a1ControllerGetPolicyInstanceStatusOK"+"."+"ackStatus", "body", o.AckStatus); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var a1ControllerGetPolicyInstanceStatusOKBodyTypeAggregateStatusPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["ALL_OK","PARTIAL","FAILED"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		a1ControllerGetPolicyInstanceStatusOKBodyTypeAggregateStatusPropEnum = append(a1ControllerGetPolicyInstanceStatusOKBodyTypeAggregateStatusPropEnum, v)
+	}
+}
+
+const (
+
+	// A1ControllerGetPolicyInstanceStatusOKBodyAggregateStatusALLOK captures enum value "ALL_OK"
+	A1ControllerGetPolicyInstanceStatusOKBodyAggregateStatusALLOK string = "ALL_OK"
+
+	// A1ControllerGetPolicyInstanceStatusOKBodyAggregateStatusPARTIAL captures enum value "PARTIAL"
+	A1ControllerGetPolicyInstanceStatusOKBodyAggregateStatusPARTIAL string = "PARTIAL"
+
+	// A1ControllerGetPolicyInstanceStatusOKBodyAggregateStatusFAILED captures enum value "FAILED"
+	A1ControllerGetPolicyInstanceStatusOKBodyAggregateStatusFAILED string = "FAILED"
+)
+
+// prop value enum
+func (o *A1ControllerGetPolicyInstanceStatusOKBody) validateAggregateStatusEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, a1ControllerGetPolicyInstanceStatusOKBodyTypeAggregateStatusPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (o *A1ControllerGetPolicyInstanceStatusOKBody) validateAggregateStatus(formats strfmt.Registry) error {
+	if swag.IsZero(o.AggregateStatus) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := o.validateAggregateStatusEnum("a1ControllerGetPolicyInstanceStatusOK"+"."+"aggregateStatus", "body", o.AggregateStatus); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 var a1ControllerGetPolicyInstanceStatusOKBodyTypeEnforceReasonPropEnum []interface{}
 
 func init() {
@@ -201,8 +342,152 @@ func (o *A1ControllerGetPolicyInstanceStatusOKBody) validateEnforceStatus(format
 	return nil
 }
 
+func (o *A1ControllerGetPolicyInstanceStatusOKBody) validateHandlers(formats strfmt.Registry) error {
+	if swag.IsZero(o.Handlers) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(o.Handlers); i++ {
+		if swag.IsZero(o.Handlers[i]) { // not required
+			continue
+		}
+
+		if o.Handlers[i] != nil {
+			if err := o.Handlers[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("a1ControllerGetPolicyInstanceStatusOK" + "." + "handlers" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
+	return nil
+}
+
+var a1ControllerGetPolicyInstanceStatusOKBodyTypeInstanceStatePropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["PENDING","ENFORCED","NOT_ENFORCED","DELETING","DELETED"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		a1ControllerGetPolicyInstanceStatusOKBodyTypeInstanceStatePropEnum = append(a1ControllerGetPolicyInstanceStatusOKBodyTypeInstanceStatePropEnum, v)
+	}
+}
+
+const (
+
+	// A1ControllerGetPolicyInstanceStatusOKBodyInstanceStatePENDING captures enum value "PENDING"
+	A1ControllerGetPolicyInstanceStatusOKBodyInstanceStatePENDING string = "PENDING"
+
+	// A1ControllerGetPolicyInstanceStatusOKBodyInstanceStateENFORCED captures enum value "ENFORCED"
+	A1ControllerGetPolicyInstanceStatusOKBodyInstanceStateENFORCED string = "ENFORCED"
+
+	// A1ControllerGetPolicyInstanceStatusOKBodyInstanceStateNOTENFORCED captures enum value "NOT_ENFORCED"
+	A1ControllerGetPolicyInstanceStatusOKBodyInstanceStateNOTENFORCED string = "NOT_ENFORCED"
+
+	// A1ControllerGetPolicyInstanceStatusOKBodyInstanceStateDELETING captures enum value "DELETING"
+	A1ControllerGetPolicyInstanceStatusOKBodyInstanceStateDELETING string = "DELETING"
+
+	// A1ControllerGetPolicyInstanceStatusOKBodyInstanceStateDELETED captures enum value "DELETED"
+	A1ControllerGetPolicyInstanceStatusOKBodyInstanceStateDELETED string = "DELETED"
+)
+
+// prop value enum
+func (o *A1ControllerGetPolicyInstanceStatusOKBody) validateInstanceStateEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, a1ControllerGetPolicyInstanceStatusOKBodyTypeInstanceStatePropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (o *A1ControllerGetPolicyInstanceStatusOKBody) validateInstanceState(formats strfmt.Registry) error {
+	if swag.IsZero(o.InstanceState) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := o.validateInstanceStateEnum("a1ControllerGetPolicyInstanceStatusOK"+"."+"instanceState", "body", o.InstanceState); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+var a1ControllerGetPolicyInstanceStatusOKBodyTypeScheduleStatusPropEnum []interface{}
+
+func init() {
+	var res []string
+	if err := json.Unmarshal([]byte(`["PENDING","ACTIVE"]`), &res); err != nil {
+		panic(err)
+	}
+	for _, v := range res {
+		a1ControllerGetPolicyInstanceStatusOKBodyTypeScheduleStatusPropEnum = append(a1ControllerGetPolicyInstanceStatusOKBodyTypeScheduleStatusPropEnum, v)
+	}
+}
+
+const (
+
+	// A1ControllerGetPolicyInstanceStatusOKBodyScheduleStatusPENDING captures enum value "PENDING"
+	A1ControllerGetPolicyInstanceStatusOKBodyScheduleStatusPENDING string = "PENDING"
+
+	// A1ControllerGetPolicyInstanceStatusOKBodyScheduleStatusACTIVE captures enum value "ACTIVE"
+	A1ControllerGetPolicyInstanceStatusOKBodyScheduleStatusACTIVE string = "ACTIVE"
+)
+
+// prop value enum
+func (o *A1ControllerGetPolicyInstanceStatusOKBody) validateScheduleStatusEnum(path, location string, value string) error {
+	if err := validate.EnumCase(path, location, value, a1ControllerGetPolicyInstanceStatusOKBodyTypeScheduleStatusPropEnum, true); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (o *A1ControllerGetPolicyInstanceStatusOKBody) validateScheduleStatus(formats strfmt.Registry) error {
+	if swag.IsZero(o.ScheduleStatus) { // not required
+		return nil
+	}
+
+	// value enum
+	if err := o.validateScheduleStatusEnum("a1ControllerGetPolicyInstanceStatusOK"+"."+"scheduleStatus", "body", o.ScheduleStatus); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // ContextValidate validates this a1 controller get policy instance status o k body based on context it is used
 func (o *A1ControllerGetPolicyInstanceStatusOKBody) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := o.contextValidateHandlers(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (o *A1ControllerGetPolicyInstanceStatusOKBody) contextValidateHandlers(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(o.Handlers); i++ {
+
+		if o.Handlers[i] != nil {
+			if err := o.Handlers[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("a1ControllerGetPolicyInstanceStatusOK" + "." + "handlers" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
 	return nil
 }
 
@@ -96,13 +96,23 @@ func (r *Restful) setupHandler() *operations.A1API {
 		if params.NotificationDestination != nil {
 			notificationDestination = *params.NotificationDestination
 		}
-		if err = r.rh.CreatePolicyInstance(models.PolicyTypeID(params.PolicyTypeID), models.PolicyInstanceID(params.PolicyInstanceID), params.Body, notificationDestination); err == nil {
+		var validFrom, validUntil string
+		if params.ValidFrom != nil {
+			validFrom = *params.ValidFrom
+		}
+		if params.ValidUntil != nil {
+			validUntil = *params.ValidUntil
+		}
+		if err = r.rh.CreatePolicyInstance(models.PolicyTypeID(params.PolicyTypeID), models.PolicyInstanceID(params.PolicyInstanceID), params.Body, notificationDestination, validFrom, validUntil, params.RanNames); err == nil {
 
 			return a1_mediator.NewA1ControllerCreateOrReplacePolicyInstanceAccepted()
 		}
-		if r.rh.IsValidJson(err) {
+		if r.rh.IsValidJson(err) || r.rh.IsValidityWindowInvalid(err) {
 			return a1_mediator.NewA1ControllerCreateOrReplacePolicyInstanceBadRequest()
 		}
+		if r.rh.IsInvalidStateTransition(err) {
+			return a1_mediator.NewA1ControllerCreateOrReplacePolicyInstanceConflict()
+		}
 		return a1_mediator.NewA1ControllerCreateOrReplacePolicyInstanceServiceUnavailable()
 
 	})
@@ -155,9 +165,82 @@ func (r *Restful) setupHandler() *operations.A1API {
 		return a1_mediator.NewA1ControllerGetPolicyInstanceStatusServiceUnavailable()
 	})
 
+	api.A1MediatorA1ControllerGetPolicyInstanceStatusHistoryHandler = a1_mediator.A1ControllerGetPolicyInstanceStatusHistoryHandlerFunc(func(params a1_mediator.A1ControllerGetPolicyInstanceStatusHistoryParams) middleware.Responder {
+		a1.Logger.Debug("handler for get policy instance status history")
+		if resp, err := r.rh.GetPolicyInstanceStatusHistory(models.PolicyTypeID(params.PolicyTypeID), models.PolicyInstanceID(params.PolicyInstanceID)); err == nil {
+			return a1_mediator.NewA1ControllerGetPolicyInstanceStatusHistoryOK().WithPayload(resp)
+		} else if r.rh.IsPolicyInstanceNotFound(err) || r.rh.IsPolicyTypeNotFound(err) {
+			return a1_mediator.NewA1ControllerGetPolicyInstanceStatusHistoryNotFound()
+		}
+		return a1_mediator.NewA1ControllerGetPolicyInstanceStatusHistoryServiceUnavailable()
+	})
+
+	api.A1MediatorA1ControllerGetPolicyInstanceFeedbackHandler = a1_mediator.A1ControllerGetPolicyInstanceFeedbackHandlerFunc(func(params a1_mediator.A1ControllerGetPolicyInstanceFeedbackParams) middleware.Responder {
+		a1.Logger.Debug("handler for get policy instance feedback")
+		if resp, err := r.rh.GetPolicyInstanceFeedback(models.PolicyTypeID(params.PolicyTypeID), models.PolicyInstanceID(params.PolicyInstanceID)); err == nil {
+			return a1_mediator.NewA1ControllerGetPolicyInstanceFeedbackOK().WithPayload(resp)
+		} else if r.rh.IsPolicyInstanceNotFound(err) || r.rh.IsPolicyTypeNotFound(err) {
+			return a1_mediator.NewA1ControllerGetPolicyInstanceFeedbackNotFound()
+		}
+		return a1_mediator.NewA1ControllerGetPolicyInstanceFeedbackServiceUnavailable()
+	})
+
+	api.A1MediatorA1ControllerGetAllInstanceStatusForTypeHandler = a1_mediator.A1ControllerGetAllInstanceStatusForTypeHandlerFunc(func(params a1_mediator.A1ControllerGetAllInstanceStatusForTypeParams) middleware.Responder {
+		a1.Logger.Debug("handler for get all policy instance status for type")
+		if resp, err := r.rh.GetAllPolicyInstanceStatus(models.PolicyTypeID(params.PolicyTypeID)); err == nil {
+			return a1_mediator.NewA1ControllerGetAllInstanceStatusForTypeOK().WithPayload(resp)
+		} else if r.rh.IsPolicyTypeNotFound(err) {
+			return a1_mediator.NewA1ControllerGetAllInstanceStatusForTypeNotFound()
+		}
+		return a1_mediator.NewA1ControllerGetAllInstanceStatusForTypeServiceUnavailable()
+	})
+
+	api.A1MediatorA1ControllerGetPolicyTypeHandlersHandler = a1_mediator.A1ControllerGetPolicyTypeHandlersHandlerFunc(func(params a1_mediator.A1ControllerGetPolicyTypeHandlersParams) middleware.Responder {
+		a1.Logger.Debug("handler for get policy type handlers")
+		if resp, err := r.rh.GetPolicyTypeHandlers(models.PolicyTypeID(params.PolicyTypeID)); err == nil {
+			return a1_mediator.NewA1ControllerGetPolicyTypeHandlersOK().WithPayload(resp)
+		} else if r.rh.IsPolicyTypeNotFound(err) {
+			return a1_mediator.NewA1ControllerGetPolicyTypeHandlersNotFound()
+		}
+		return a1_mediator.NewA1ControllerGetPolicyTypeHandlersServiceUnavailable()
+	})
+
+	api.A1MediatorA1ControllerGetStatusSummaryHandler = a1_mediator.A1ControllerGetStatusSummaryHandlerFunc(func(params a1_mediator.A1ControllerGetStatusSummaryParams) middleware.Responder {
+		a1.Logger.Debug("handler for get policy status summary")
+		if resp, err := r.rh.GetPolicyStatusSummary(); err == nil {
+			return a1_mediator.NewA1ControllerGetStatusSummaryOK().WithPayload(resp)
+		}
+		return a1_mediator.NewA1ControllerGetStatusSummaryServiceUnavailable()
+	})
+
+	api.A1MediatorA1ControllerResyncPoliciesHandler = a1_mediator.A1ControllerResyncPoliciesHandlerFunc(func(params a1_mediator.A1ControllerResyncPoliciesParams) middleware.Responder {
+		a1.Logger.Debug("handler for resync policies")
+		var policyTypeId *models.PolicyTypeID
+		if params.PolicyTypeID != nil {
+			id := models.PolicyTypeID(*params.PolicyTypeID)
+			policyTypeId = &id
+		}
+		if err := r.rh.ResyncPolicies(policyTypeId); err != nil {
+			if r.rh.IsPolicyTypeNotFound(err) {
+				return a1_mediator.NewA1ControllerResyncPoliciesNotFound()
+			}
+			return a1_mediator.NewA1ControllerResyncPoliciesServiceUnavailable()
+		}
+		return a1_mediator.NewA1ControllerResyncPoliciesAccepted()
+	})
+
+	api.A1MediatorA1ControllerGetRmrDeadLettersHandler = a1_mediator.A1ControllerGetRmrDeadLettersHandlerFunc(func(params a1_mediator.A1ControllerGetRmrDeadLettersParams) middleware.Responder {
+		a1.Logger.Debug("handler for get rmr dead letters")
+		if resp, err := r.rh.GetRmrDeadLetters(); err == nil {
+			return a1_mediator.NewA1ControllerGetRmrDeadLettersOK().WithPayload(resp)
+		}
+		return a1_mediator.NewA1ControllerGetRmrDeadLettersServiceUnavailable()
+	})
+
 	api.A1MediatorA1ControllerDeletePolicyInstanceHandler = a1_mediator.A1ControllerDeletePolicyInstanceHandlerFunc(func(params a1_mediator.A1ControllerDeletePolicyInstanceParams) middleware.Responder {
 		a1.Logger.Debug("handler for delete policy instance")
-		if err := r.rh.DeletePolicyInstance(models.PolicyTypeID(params.PolicyTypeID), models.PolicyInstanceID(params.PolicyInstanceID)); err != nil {
+		force := params.Force != nil && *params.Force
+		if err := r.rh.DeletePolicyInstance(models.PolicyTypeID(params.PolicyTypeID), models.PolicyInstanceID(params.PolicyInstanceID), force); err != nil {
 			if r.rh.CanPolicyInstanceBeDeleted(err) {
 				return a1_mediator.NewA1ControllerDeletePolicyInstanceNotFound()
 			}
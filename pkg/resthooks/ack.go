@@ -0,0 +1,170 @@
+/*
+==================================================================================
+  Copyright (c) 2021 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+package resthooks
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/metrics"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/models"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/notification"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/policy"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/restapi/operations/a1_mediator"
+)
+
+const (
+	ackPending         = a1_mediator.A1ControllerGetPolicyInstanceStatusOKBodyAckStatusPENDING
+	ackNotAcknowledged = a1_mediator.A1ControllerGetPolicyInstanceStatusOKBodyAckStatusNOTACKNOWLEDGED
+)
+
+func policyAckKey(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID) string {
+	return a1AckPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID)
+}
+
+// trackPolicyAck marks the instance as waiting for an A1_POLICY_RESP and arms
+// the acknowledgement deadline. A new request restarts the deadline. It is
+// called before the request is sent, as the response may be handled before the
+// send returns, and tells whether the ack is tracked along with the previous ack
+// status, which untrackPolicyAck restores when the request could not be sent.
+func (rh *Resthook) trackPolicyAck(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID) (string, bool) {
+	if rh.ackTimeout <= 0 {
+		return "", false
+	}
+	previous, err := rh.getPolicyAckStatus(policyTypeId, policyInstanceID)
+	if err != nil {
+		return "", false
+	}
+	ackKey := policyAckKey(policyTypeId, policyInstanceID)
+	if err := rh.db.Set(a1MediatorNs, ackKey, ackPending); err != nil {
+		a1.Logger.Error("error :%+v", err)
+		return "", false
+	}
+	rh.ackTimers.cancel(ackKey)
+	rh.ackTimers.add(ackKey, time.Now().Add(rh.ackTimeout), func() {
+		rh.checkPolicyAck(policyTypeId, policyInstanceID)
+	})
+	return previous, true
+}
+
+// untrackPolicyAck cancels the acknowledgement deadline of a request that could
+// not be sent and restores the previous ack status
+func (rh *Resthook) untrackPolicyAck(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID, previous string) {
+	ackKey := policyAckKey(policyTypeId, policyInstanceID)
+	rh.ackTimers.cancel(ackKey)
+	var err error
+	if len(previous) == 0 {
+		err = rh.db.Remove(a1MediatorNs, []string{ackKey})
+	} else {
+		err = rh.db.Set(a1MediatorNs, ackKey, previous)
+	}
+	if err != nil {
+		a1.Logger.Error("error :%+v", err)
+	}
+}
+
+func (rh *Resthook) getPolicyAckStatus(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID) (string, error) {
+	var keys [1]string
+	ackKey := policyAckKey(policyTypeId, policyInstanceID)
+	keys[0] = ackKey
+	valmap, err := rh.db.Get(a1MediatorNs, keys[:])
+	if err != nil {
+		a1.Logger.Error("error in retrieving policy ack status err: %v", err)
+		return "", err
+	}
+	ackStatus, _ := valmap[ackKey].(string)
+	return ackStatus, nil
+}
+
+// checkPolicyAck runs when the acknowledgement deadline of an instance passes.
+// If still no xApp has answered, the instance is marked as not acknowledged and
+// the notification destination is informed.
+func (rh *Resthook) checkPolicyAck(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID) {
+	ackStatus, err := rh.getPolicyAckStatus(policyTypeId, policyInstanceID)
+	if err != nil || ackStatus != ackPending {
+		return
+	}
+	a1.Logger.Warning("policy instance %d.%s not acknowledged by any xApp within %v", policyTypeId, policyInstanceID, rh.ackTimeout)
+	// a response may have been recorded since the ack was read
+	stored, err := rh.db.SetIf(a1MediatorNs, policyAckKey(policyTypeId, policyInstanceID), ackPending, ackNotAcknowledged)
+	if err != nil || !stored {
+		return
+	}
+	instanceMetadataKey := a1InstanceMetadataPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID)
+	var keys [1]string
+	keys[0] = instanceMetadataKey
+	valmap, err := rh.db.Get(a1MediatorNs, keys[:])
+	if err != nil {
+		a1.Logger.Error("policy instance error : %v", err)
+		return
+	}
+	metadata, _ := valmap[instanceMetadataKey].(string)
+	_, err = policy.UpdateInstanceMetadata(rh.db, instanceMetadataKey, metadata, func(metadata string) (string, error) {
+		if policy.InstanceMetadataState(metadata) != policy.InstanceStatePending {
+			return metadata, nil
+		}
+		return policy.SetInstanceMetadataState(metadata, policy.InstanceStateNotEnforced)
+	})
+	if err != nil {
+		a1.Logger.Error("error :%+v", err)
+		return
+	}
+	metrics.IncCounter(metrics.PolicyAckTimeout)
+	if err = rh.sendPolicyStatusNotification(policyTypeId, policyInstanceID); err != nil {
+		a1.Logger.Error("failed to send policy status notification : %v", err)
+	}
+}
+
+// sendPolicyStatusNotification posts the current status of the instance to the
+// notification destination given when it was created, if any
+func (rh *Resthook) sendPolicyStatusNotification(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID) error {
+	var keys [1]string
+	notificationDestinationkey := a1NotificationDestinationPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID)
+	keys[0] = notificationDestinationkey
+	valmap, err := rh.db.Get(a1MediatorNs, keys[:])
+	if err != nil {
+		a1.Logger.Error("error in retrieving notification destination err: %v", err)
+		return err
+	}
+	notificationDestination, ok := valmap[notificationDestinationkey].(string)
+	if !ok || len(notificationDestination) == 0 {
+		return nil
+	}
+	instanceMetadataKey := a1InstanceMetadataPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID)
+	keys[0] = instanceMetadataKey
+	if valmap, err = rh.db.Get(a1MediatorNs, keys[:]); err != nil {
+		a1.Logger.Error("policy instance error : %v", err)
+		return err
+	}
+	metadata, _ := valmap[instanceMetadataKey].(string)
+	policyInstanceStatus, err := rh.policyStatusBody(policyTypeId, policyInstanceID, policy.InstanceMetadataState(metadata))
+	if err != nil {
+		return err
+	}
+	jsonbody, err := json.Marshal(policyInstanceStatus)
+	if err != nil {
+		a1.Logger.Error("marshal error : %v", err)
+		return err
+	}
+	return notification.SendNotification(notificationDestination, string(jsonbody))
+}
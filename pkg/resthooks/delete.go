@@ -0,0 +1,88 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+package resthooks
+
+import (
+	"strconv"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/metrics"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/models"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/policy"
+)
+
+func policyInstanceMetadataKey(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID) string {
+	return a1InstanceMetadataPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID)
+}
+
+// startPolicyInstanceDelete marks the instance as DELETING, sends the DELETE to
+// the xApps and arms the delete deadline. The xApps acknowledge the deletion with
+// an A1_POLICY_RESP carrying the DELETED status.
+func (rh *Resthook) startPolicyInstanceDelete(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID, metadata string) error {
+	instanceMetadataKey := policyInstanceMetadataKey(policyTypeId, policyInstanceID)
+	_, err := policy.UpdateInstanceMetadata(rh.db, instanceMetadataKey, metadata, func(metadata string) (string, error) {
+		if err := policy.ValidateStateTransition(policy.InstanceMetadataState(metadata), policy.InstanceStateDeleting); err != nil {
+			return "", err
+		}
+		deletingMetadata, err := policy.SetInstanceMetadataState(metadata, policy.InstanceStateDeleting)
+		if err != nil {
+			a1.Logger.Error("unmarshal error : %v", err)
+		}
+		return deletingMetadata, err
+	})
+	if err != nil {
+		return err
+	}
+	rh.deletePolicySchedule(policyTypeId, policyInstanceID)
+	rh.ackTimers.cancel(policyAckKey(policyTypeId, policyInstanceID))
+
+	rh.deleteTimers.cancel(instanceMetadataKey)
+	rh.deleteTimers.add(instanceMetadataKey, time.Now().Add(rh.deleteTimeout), func() {
+		rh.checkPolicyInstanceDelete(policyTypeId, policyInstanceID)
+	})
+	return rh.sendPolicyRequest(policyTypeId, policyInstanceID, "", "DELETE", false)
+}
+
+// checkPolicyInstanceDelete runs when the delete deadline of an instance passes.
+// If the xApps have not all acknowledged the deletion yet, the instance is removed
+// anyway and the notification destination is informed.
+func (rh *Resthook) checkPolicyInstanceDelete(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID) {
+	instanceMetadataKey := policyInstanceMetadataKey(policyTypeId, policyInstanceID)
+	notificationDestinationkey := a1NotificationDestinationPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID)
+	valmap, err := rh.db.Get(a1MediatorNs, []string{instanceMetadataKey, notificationDestinationkey})
+	if err != nil {
+		a1.Logger.Error("policy instance error : %v", err)
+		return
+	}
+	metadata, _ := valmap[instanceMetadataKey].(string)
+	if policy.InstanceMetadataState(metadata) != policy.InstanceStateDeleting {
+		return
+	}
+	a1.Logger.Warning("policy instance %d.%s deletion not acknowledged by the xApps within %v, removing it", policyTypeId, policyInstanceID, rh.deleteTimeout)
+	metrics.IncCounter(metrics.PolicyDeleteTimeout)
+	rh.removePolicyInstance(policyTypeId, policyInstanceID, metadataCreatedAt(metadata))
+	if notificationDestination, ok := valmap[notificationDestinationkey].(string); ok && len(notificationDestination) > 0 {
+		if err = policy.SendPolicyDeletedNotification(notificationDestination); err != nil {
+			a1.Logger.Error("failed to send policy deleted notification : %v", err)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+/*
+==================================================================================
+  Copyright (c) 2021 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+package resthooks
+
+import (
+	"strconv"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/models"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/policy"
+)
+
+// GetPolicyInstanceFeedback returns the feedback samples the xApps reported for
+// the instance, oldest first
+func (rh *Resthook) GetPolicyInstanceFeedback(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID) ([]*models.PolicyFeedback, error) {
+	if err := rh.instanceValidity(policyTypeId, policyInstanceID); err != nil {
+		return nil, err
+	}
+	feedbackKey := a1FeedbackPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID)
+	valmap, err := rh.db.Get(a1MediatorNs, []string{feedbackKey})
+	if err != nil {
+		a1.Logger.Error("error in retrieving policy feedback err: %v", err)
+		return nil, err
+	}
+	samples, err := policy.ParsePolicyFeedback(valmap[feedbackKey])
+	if err != nil {
+		a1.Logger.Error("unmarshal error : %v", err)
+		return nil, err
+	}
+	return policy.PolicyFeedbackList(samples), nil
+}
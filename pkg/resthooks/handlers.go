@@ -0,0 +1,64 @@
+/*
+==================================================================================
+  Copyright (c) 2021 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+package resthooks
+
+import (
+	"strconv"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/models"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/policy"
+)
+
+func (rh *Resthook) getPolicyTypeHandlers(policyTypeId models.PolicyTypeID) (map[string]policy.PolicyTypeHandler, error) {
+	typeHandlerKey := a1TypeHandlerPrefix + strconv.FormatInt((int64(policyTypeId)), 10)
+	valmap, err := rh.db.Get(a1MediatorNs, []string{typeHandlerKey})
+	if err != nil {
+		a1.Logger.Error("error in retrieving policy type handlers err: %v", err)
+		return nil, err
+	}
+	return policy.ParsePolicyTypeHandlers(valmap[typeHandlerKey])
+}
+
+// GetPolicyTypeHandlers returns the xApps that reported the status of an
+// instance of the policy type
+func (rh *Resthook) GetPolicyTypeHandlers(policyTypeId models.PolicyTypeID) ([]*models.PolicyTypeHandler, error) {
+	if err := rh.typeValidity(policyTypeId); err != nil {
+		return nil, err
+	}
+	handlers, err := rh.getPolicyTypeHandlers(policyTypeId)
+	if err != nil {
+		return nil, err
+	}
+	return policy.PolicyTypeHandlerList(handlers), nil
+}
+
+// warnIfNoPolicyTypeHandler warns that the instances of a policy type may not be
+// enforced when no xApp has ever handled the type
+func (rh *Resthook) warnIfNoPolicyTypeHandler(policyTypeId models.PolicyTypeID) {
+	handlers, err := rh.getPolicyTypeHandlers(policyTypeId)
+	if err != nil {
+		return
+	}
+	if len(handlers) == 0 {
+		a1.Logger.Warning("policy type %d has no known handler, the policy instance may not be enforced", policyTypeId)
+	}
+}
@@ -0,0 +1,237 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+package resthooks
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/metrics"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/models"
+)
+
+// rmrRetryPolicy bounds the retries of the RMR messages that failed to be sent.
+// The delay before a retry doubles after every attempt up to maxBackoff.
+type rmrRetryPolicy struct {
+	maxAttempts int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+}
+
+func (p rmrRetryPolicy) delay(attempts int) time.Duration {
+	delay := p.backoff
+	for i := 1; i < attempts && delay < p.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > p.maxBackoff {
+		delay = p.maxBackoff
+	}
+	return delay
+}
+
+var outboxSequence uint64
+
+func newOutboxMessageID() string {
+	return strconv.FormatInt(time.Now().UnixNano(), 10) + "." + strconv.FormatUint(atomic.AddUint64(&outboxSequence, 1), 10)
+}
+
+// policyOutboxMessageID identifies the policy request of an instance for a RAN
+// node in the outbox. The ids of all the nodes of an instance share the prefix
+// returned for an empty RAN node.
+func policyOutboxMessageID(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID, ranName string) string {
+	return strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID) + "/" + ranName
+}
+
+// rmrSend sends the message to the xApps serving its RAN node, or to every xApp
+// when no node is given
+func (rh *Resthook) rmrSend(msg *models.RmrMessage) bool {
+	if len(msg.RanName) == 0 {
+		return rh.iRmrSenderInst.RmrSendToXapp(msg.Payload, int(msg.MessageType), int(msg.SubID), msg.TransactionID)
+	}
+	return rh.iRmrSenderInst.RmrSendToNode(msg.Payload, int(msg.MessageType), int(msg.SubID), msg.RanName, msg.TransactionID)
+}
+
+// sendRmrMessage sends the message to the xApps. A message RMR fails to send is
+// kept in the SDL outbox and sent again later, so it survives a restart. A message
+// without an id is given a unique one.
+func (rh *Resthook) sendRmrMessage(msg models.RmrMessage) bool {
+	if rh.rmrSend(&msg) {
+		return true
+	}
+	if len(msg.ID) == 0 {
+		msg.ID = newOutboxMessageID()
+	}
+	now := time.Now().Format(time.RFC3339)
+	msg.Attempts = 1
+	msg.CreatedAt = now
+	msg.LastAttemptAt = now
+	rh.scheduleRmrRetry(&msg)
+	return false
+}
+
+// dropPolicyRmrMessages drops the requests of the instance still waiting in the
+// outbox, the request about to be sent supersedes them
+func (rh *Resthook) dropPolicyRmrMessages(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID) {
+	outboxKeys := rh.outbox.cancelPrefix(a1OutboxPrefix + policyOutboxMessageID(policyTypeId, policyInstanceID, ""))
+	if len(outboxKeys) == 0 {
+		return
+	}
+	a1.Logger.Debug("dropping %d queued requests of policy instance %d.%s", len(outboxKeys), policyTypeId, policyInstanceID)
+	if err := rh.db.Remove(a1MediatorNs, outboxKeys); err != nil {
+		a1.Logger.Error("error in deleting outbox message err: %v", err)
+	}
+}
+
+// scheduleRmrRetry stores the message in the outbox and arms its next attempt,
+// or moves it to the dead letters once all attempts are used
+func (rh *Resthook) scheduleRmrRetry(msg *models.RmrMessage) {
+	outboxKey := a1OutboxPrefix + msg.ID
+	if int(msg.Attempts) >= rh.rmrRetry.maxAttempts {
+		rh.deadLetterRmrMessage(msg)
+		return
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		a1.Logger.Error("marshal error : %v", err)
+		return
+	}
+	if err = rh.db.Set(a1MediatorNs, outboxKey, string(data)); err != nil {
+		a1.Logger.Error("error :%+v", err)
+		return
+	}
+	rh.outbox.cancel(outboxKey)
+	rh.outbox.add(outboxKey, time.Now().Add(rh.rmrRetry.delay(int(msg.Attempts))), func() {
+		rh.resendRmrMessage(msg)
+	})
+}
+
+func (rh *Resthook) resendRmrMessage(msg *models.RmrMessage) {
+	outboxKey := a1OutboxPrefix + msg.ID
+	rh.outbox.cancel(outboxKey)
+	metrics.IncCounter(metrics.RmrSendRetry)
+	policyTypeId, policyInstanceID := models.PolicyTypeID(msg.PolicyTypeID), models.PolicyInstanceID(msg.PolicyInstanceID)
+	var previousAck string
+	ackTracked := false
+	if len(msg.PolicyInstanceID) > 0 && msg.Operation != "DELETE" {
+		previousAck, ackTracked = rh.trackPolicyAck(policyTypeId, policyInstanceID)
+	}
+	if rh.rmrSend(msg) {
+		a1.Logger.Debug("rmrSendToXapp : message %s sent after %d attempts", msg.ID, msg.Attempts+1)
+		if err := rh.db.Remove(a1MediatorNs, []string{outboxKey}); err != nil {
+			a1.Logger.Error("error in deleting outbox message err: %v", err)
+		}
+		return
+	}
+	if ackTracked {
+		rh.untrackPolicyAck(policyTypeId, policyInstanceID, previousAck)
+	}
+	msg.Attempts++
+	msg.LastAttemptAt = time.Now().Format(time.RFC3339)
+	rh.scheduleRmrRetry(msg)
+}
+
+func (rh *Resthook) deadLetterRmrMessage(msg *models.RmrMessage) {
+	a1.Logger.Error("rmrSendToXapp : message %s of type %d not sent after %d attempts, giving up", msg.ID, msg.MessageType, msg.Attempts)
+	metrics.IncCounter(metrics.RmrDeadLetter)
+	data, err := json.Marshal(msg)
+	if err != nil {
+		a1.Logger.Error("marshal error : %v", err)
+		return
+	}
+	if err = rh.db.Set(a1MediatorNs, a1DeadLetterPrefix+msg.ID, string(data)); err != nil {
+		a1.Logger.Error("error :%+v", err)
+		return
+	}
+	if msg.Attempts > 1 {
+		if err = rh.db.Remove(a1MediatorNs, []string{a1OutboxPrefix + msg.ID}); err != nil {
+			a1.Logger.Error("error in deleting outbox message err: %v", err)
+		}
+	}
+}
+
+// getRmrMessages returns the messages stored under the given prefix, oldest first
+func (rh *Resthook) getRmrMessages(prefix string) ([]*models.RmrMessage, error) {
+	keys, err := rh.db.GetAll(a1MediatorNs)
+	if err != nil {
+		a1.Logger.Error("error in retrieving keys err: %v", err)
+		return nil, err
+	}
+	var messageKeys []string
+	for _, key := range keys {
+		if strings.HasPrefix(key, prefix) {
+			messageKeys = append(messageKeys, key)
+		}
+	}
+	messages := []*models.RmrMessage{}
+	if len(messageKeys) == 0 {
+		return messages, nil
+	}
+	valmap, err := rh.db.Get(a1MediatorNs, messageKeys)
+	if err != nil {
+		a1.Logger.Error("error in retrieving rmr messages err: %v", err)
+		return nil, err
+	}
+	for _, key := range messageKeys {
+		data, ok := valmap[key].(string)
+		if !ok {
+			continue
+		}
+		var msg models.RmrMessage
+		if err := json.Unmarshal([]byte(data), &msg); err != nil {
+			a1.Logger.Error("unmarshal error : %v", err)
+			continue
+		}
+		messages = append(messages, &msg)
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].CreatedAt < messages[j].CreatedAt
+	})
+	return messages, nil
+}
+
+// GetRmrDeadLetters returns the messages that could not be sent after all retries
+func (rh *Resthook) GetRmrDeadLetters() ([]*models.RmrMessage, error) {
+	a1.Logger.Debug("GetRmrDeadLetters")
+	return rh.getRmrMessages(a1DeadLetterPrefix)
+}
+
+// restoreRmrOutbox re-arms the retries of the messages left in the outbox when
+// the mediator stopped. RMR is not ready yet when the mediator starts, so the
+// messages are sent again after their backoff rather than right away.
+func (rh *Resthook) restoreRmrOutbox() {
+	messages, err := rh.getRmrMessages(a1OutboxPrefix)
+	if err != nil {
+		a1.Logger.Error("failed to restore rmr outbox : %v", err)
+		return
+	}
+	for _, msg := range messages {
+		msg := msg
+		a1.Logger.Info("resuming rmr message %s of type %d", msg.ID, msg.MessageType)
+		rh.outbox.add(a1OutboxPrefix+msg.ID, time.Now().Add(rh.rmrRetry.delay(int(msg.Attempts))), func() {
+			rh.resendRmrMessage(msg)
+		})
+	}
+}
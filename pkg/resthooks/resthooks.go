@@ -26,10 +26,12 @@
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/config"
 	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
 	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/models"
 	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/policy"
@@ -47,8 +49,15 @@
 	a1NotificationDestinationPrefix = "a1.policy_notification_destination."
 	a1InstanceMetadataPrefix        = "a1.policy_inst_metadata."
 	a1HandlerPrefix                 = "a1.policy_handler."
-	a1PolicyRequest                 = 20010
-	a1EIDataDelivery                = 20017
+	a1SchedulePrefix                = "a1.policy_schedule."
+	a1HandlerStatusPrefix           = "a1.policy_handler_status."
+	a1AckPrefix                     = "a1.policy_ack."
+	a1OutboxPrefix                  = "a1.rmr_outbox."
+	a1DeadLetterPrefix              = "a1.rmr_dead_letter."
+	a1TransactionPrefix             = "a1.policy_transaction."
+	a1TypeHandlerPrefix             = "a1.policy_type_handler."
+	a1StatusHistoryPrefix           = "a1.policy_status_history."
+	a1FeedbackPrefix                = "a1.policy_feedback."
 )
 
 var typeAlreadyError = errors.New("Policy Type already exists")
@@ -59,6 +68,7 @@
 var policyTypeNotFoundError = errors.New("Policy Type Not Found")
 var policyTypeCanNotBeDeletedError = errors.New("tried to delete a type that isn't empty")
 var policyInstanceCanNotBeDeletedError = errors.New("tried to delete a Instance that isn't empty")
+var invalidValidityWindowError = errors.New("Invalid policy instance validity window")
 
 func (rh *Resthook) CanPolicyInstanceBeDeleted(err error) bool {
 	return err == policyInstanceCanNotBeDeletedError
@@ -89,16 +99,48 @@ func (rh *Resthook) IsTypeMismatch(err error) bool {
 func (rh *Resthook) IsValidJson(err error) bool {
 	return err == invalidJsonSchema
 }
+
+func (rh *Resthook) IsInvalidStateTransition(err error) bool {
+	return policy.IsInvalidStateTransition(err)
+}
+
+func (rh *Resthook) IsValidityWindowInvalid(err error) bool {
+	return err == invalidValidityWindowError
+}
 func NewResthook() *Resthook {
 	sdl := sdlgo.NewSyncStorage()
 	policyManager := policy.NewPolicyManager(sdl)
-	return createResthook(sdl, rmr.NewRMRSender(policyManager))
+	rh := createResthook(sdl, rmr.NewRMRSender(policyManager))
+	conf := config.ParseConfiguration()
+	rh.ackTimeout = time.Duration(conf.PolicyAckTimeout) * time.Second
+	rh.deleteTimeout = time.Duration(conf.PolicyDeleteTimeout) * time.Second
+	rh.messageVersion = conf.PolicyMessageVersion
+	rh.rmrRetry = rmrRetryPolicy{
+		maxAttempts: conf.RmrRetryMaxAttempts,
+		backoff:     time.Duration(conf.RmrRetryBackoff) * time.Second,
+		maxBackoff:  time.Duration(conf.RmrRetryMaxBackoff) * time.Second,
+	}
+	if conf.ResyncRate > 0 {
+		rh.resyncInterval = time.Second / time.Duration(conf.ResyncRate)
+	}
+	rh.restorePolicySchedules()
+	rh.restoreRmrOutbox()
+	if conf.ResyncOnStartup {
+		if err := rh.ResyncPolicies(nil); err != nil {
+			a1.Logger.Error("failed to resync policy instances : %v", err)
+		}
+	}
+	return rh
 }
 
 func createResthook(sdlInst iSdl, rmrSenderInst rmr.IRmrSender) *Resthook {
 	rh := &Resthook{
 		db:             sdlInst,
 		iRmrSenderInst: rmrSenderInst,
+		scheduleTimers: newTimerSet(),
+		ackTimers:      newTimerSet(),
+		deleteTimers:   newTimerSet(),
+		outbox:         newTimerSet(),
 	}
 
 	return rh
@@ -350,7 +392,9 @@ func (rh *Resthook) storePolicyInstance(policyTypeId models.PolicyTypeID, policy
 	return operation, nil
 }
 
-func (rh *Resthook) storePolicyInstanceMetadata(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID) (bool, error) {
+// storePolicyInstanceMetadata resets the metadata of the instance to PENDING.
+// previousMetadata is the metadata the state was validated against.
+func (rh *Resthook) storePolicyInstanceMetadata(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID, previousMetadata string, ranNames []string) (bool, error) {
 
 	creation_timestamp := time.Now()
 	instanceMetadataKey := a1InstanceMetadataPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID)
@@ -358,12 +402,23 @@ func (rh *Resthook) storePolicyInstanceMetadata(policyTypeId models.PolicyTypeID
 	a1.Logger.Debug("key : %+v", instanceMetadataKey)
 
 	var metadatajson []interface{}
-	metadatajson = append(metadatajson, map[string]string{"created_at": creation_timestamp.Format("2006-01-02 15:04:05"), "has_been_deleted": "False"})
+	instanceMetadata := map[string]interface{}{"created_at": creation_timestamp.Format("2006-01-02 15:04:05"), "has_been_deleted": "False", "state": policy.InstanceStatePending}
+	if len(ranNames) > 0 {
+		instanceMetadata["ran_names"] = ranNames
+	}
+	metadatajson = append(metadatajson, instanceMetadata)
 	metadata, _ := json.Marshal(metadatajson)
 
 	a1.Logger.Debug("policyinstanceMetaData to create : %+v", string(metadata))
 
-	err := rh.db.Set(a1MediatorNs, instanceMetadataKey, string(metadata))
+	// a DELETE accepted since the state was validated must not be undone
+	_, err := policy.UpdateInstanceMetadata(rh.db, instanceMetadataKey, previousMetadata, func(current string) (string, error) {
+		if err := policy.ValidateStateTransition(policy.InstanceMetadataState(current), policy.InstanceStatePending); err != nil {
+			a1.Logger.Error("policy instance %d.%s can not move from %s to %s", policyTypeId, policyInstanceID, policy.InstanceMetadataState(current), policy.InstanceStatePending)
+			return "", err
+		}
+		return string(metadata), nil
+	})
 
 	if err != nil {
 		a1.Logger.Error("error :%+v", err)
@@ -375,11 +430,15 @@ func (rh *Resthook) storePolicyInstanceMetadata(policyTypeId models.PolicyTypeID
 	return true, nil
 }
 
-func (rh *Resthook) CreatePolicyInstance(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID, httpBody interface{}, notificationDestination string) error {
+func (rh *Resthook) CreatePolicyInstance(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID, httpBody interface{}, notificationDestination string, validFrom string, validUntil string, ranNames []string) error {
 	a1.Logger.Debug("CreatePolicyInstance function")
+	validFromTime, validUntilTime, err := parseValidityWindow(validFrom, validUntil)
+	if err != nil {
+		return err
+	}
 	//  validate the PUT against the schema
 	var policyTypeSchema *models.PolicyTypeSchema
-	policyTypeSchema, err := rh.GetPolicyType(policyTypeId)
+	policyTypeSchema, err = rh.GetPolicyType(policyTypeId)
 	if err != nil {
 		a1.Logger.Error("error : %+v", err)
 		return err
@@ -398,6 +457,11 @@ func (rh *Resthook) CreatePolicyInstance(policyTypeId models.PolicyTypeID, polic
 	a1.Logger.Debug("httpbody to validate sprint %+v", httpBodyString)
 	isvalid := validate(httpBodyString, schemaString)
 	if isvalid {
+		var previousMetadata string
+		previousMetadata, err = rh.validatePolicyInstanceState(policyTypeId, policyInstanceID, policy.InstanceStatePending)
+		if err != nil {
+			return err
+		}
 		var operation string
 		operation, err = rh.storePolicyInstance(policyTypeId, policyInstanceID, httpBody, notificationDestination)
 		if err != nil {
@@ -405,7 +469,11 @@ func (rh *Resthook) CreatePolicyInstance(policyTypeId models.PolicyTypeID, polic
 			return err
 		}
 		a1.Logger.Debug("policy instance :%+v", operation)
-		iscreated, errmetadata := rh.storePolicyInstanceMetadata(policyTypeId, policyInstanceID)
+		var droppedNodes []string
+		if operation == "UPDATE" {
+			droppedNodes = droppedRanNames(previousMetadata, ranNames)
+		}
+		iscreated, errmetadata := rh.storePolicyInstanceMetadata(policyTypeId, policyInstanceID, previousMetadata, ranNames)
 		if errmetadata != nil {
 			a1.Logger.Error("error :%+v", errmetadata)
 			return errmetadata
@@ -413,25 +481,153 @@ func (rh *Resthook) CreatePolicyInstance(policyTypeId models.PolicyTypeID, polic
 		if iscreated {
 			a1.Logger.Debug("policy instance metadata created")
 		}
+		if operation == "UPDATE" {
+			// the statuses reported by the handlers apply to the previous revision
+			if err = rh.deletePolicyInstanceStatus(policyTypeId, policyInstanceID); err != nil {
+				return err
+			}
+		}
 
-		message := rmr.Message{}
-		rmrMessage, err := message.PolicyMessage(strconv.FormatInt((int64(policyTypeId)), 10), string(policyInstanceID), httpBodyString, operation)
-		if err != nil {
-			a1.Logger.Error("error : %v", err)
+		if err = rh.deletePolicySchedule(policyTypeId, policyInstanceID); err != nil {
 			return err
 		}
-		isSent := rh.iRmrSenderInst.RmrSendToXapp(rmrMessage, a1PolicyRequest, int(policyTypeId))
-		if isSent {
-			a1.Logger.Debug("rmrSendToXapp : message sent")
-		} else {
-			a1.Logger.Debug("rmrSendToXapp : message not sent")
+		if !validFromTime.IsZero() || !validUntilTime.IsZero() {
+			schedule := policySchedule{Status: scheduleActive}
+			if !validFromTime.IsZero() {
+				schedule.ValidFrom = validFromTime.Format(time.RFC3339)
+				if validFromTime.After(time.Now()) {
+					schedule.Status = schedulePending
+				}
+			}
+			if !validUntilTime.IsZero() {
+				schedule.ValidUntil = validUntilTime.Format(time.RFC3339)
+			}
+			if err = rh.storePolicySchedule(policyTypeId, policyInstanceID, schedule); err != nil {
+				return err
+			}
+			rh.schedulePolicyInstance(policyTypeId, policyInstanceID, schedule)
+			if schedule.Status == schedulePending {
+				a1.Logger.Debug("policy instance %d.%s is pending until %s", policyTypeId, policyInstanceID, schedule.ValidFrom)
+				if operation == "UPDATE" {
+					// xApps may still enforce the previous revision of the instance
+					if err = rh.sendPolicyRequest(policyTypeId, policyInstanceID, "", "DELETE", true); err != nil {
+						return err
+					}
+					return rh.sendPolicyDeleteToRanNodes(policyTypeId, policyInstanceID, droppedNodes)
+				}
+				return nil
+			}
 		}
 
+		rh.warnIfNoPolicyTypeHandler(policyTypeId)
+		if err = rh.sendPolicyRequest(policyTypeId, policyInstanceID, httpBodyString, operation, true); err != nil {
+			return err
+		}
+		return rh.sendPolicyDeleteToRanNodes(policyTypeId, policyInstanceID, droppedNodes)
 	} else {
 		a1.Logger.Error("%+v", invalidJsonSchema)
 		return invalidJsonSchema
 	}
+}
 
+// sendPolicyRequest sends a policy request to the xApps, newRevision telling
+// whether the body of the instance changed since the last request
+func (rh *Resthook) sendPolicyRequest(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID, httpBodyString string, operation string, newRevision bool) error {
+	transaction, err := rh.newPolicyTransaction(policyTypeId, policyInstanceID, operation, newRevision)
+	if err != nil {
+		return err
+	}
+	message := rmr.Message{Version: rh.messageVersion}
+	rmrMessage, err := message.PolicyMessage(strconv.FormatInt((int64(policyTypeId)), 10), string(policyInstanceID), httpBodyString, operation, transaction)
+	if err != nil {
+		a1.Logger.Error("error : %v", err)
+		return err
+	}
+	ranNames := transaction.RanNames
+	if len(ranNames) == 0 {
+		ranNames = []string{""}
+	}
+	rh.dropPolicyRmrMessages(policyTypeId, policyInstanceID)
+	var previousAck string
+	ackTracked := false
+	if operation != "DELETE" {
+		previousAck, ackTracked = rh.trackPolicyAck(policyTypeId, policyInstanceID)
+	}
+	isSent := false
+	for _, ranName := range ranNames {
+		msg := models.RmrMessage{
+			ID:               policyOutboxMessageID(policyTypeId, policyInstanceID, ranName),
+			MessageType:      int64(rmr.MessageTypeID(rmr.A1PolicyRequest)),
+			SubID:            int64(policyTypeId),
+			Payload:          rmrMessage,
+			RanName:          ranName,
+			PolicyTypeID:     int64(policyTypeId),
+			PolicyInstanceID: string(policyInstanceID),
+			Operation:        operation,
+			TransactionID:    transaction.TransactionID,
+		}
+		if rh.sendRmrMessage(msg) {
+			a1.Logger.Debug("rmrSendToXapp : message sent to %q", ranName)
+			isSent = true
+		} else {
+			a1.Logger.Error("rmrSendToXapp : message to %q not sent, queued for retry", ranName)
+		}
+	}
+	if !isSent && ackTracked {
+		rh.untrackPolicyAck(policyTypeId, policyInstanceID, previousAck)
+	}
+	return nil
+}
+
+// sendPolicyDeleteToRanNodes sends a DELETE of the instance to the RAN nodes an
+// update removed from its targets, so that they stop enforcing it. It follows
+// the request just sent to the remaining nodes, with the same sequence, but has
+// a transaction id of its own that is not recorded, so that the responses of
+// the removed nodes are ignored as stale.
+func (rh *Resthook) sendPolicyDeleteToRanNodes(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID, ranNames []string) error {
+	if len(ranNames) == 0 {
+		return nil
+	}
+	transactionKey := a1TransactionPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID)
+	valmap, err := rh.db.Get(a1MediatorNs, []string{transactionKey})
+	if err != nil {
+		a1.Logger.Error("error in retrieving policy transaction err: %v", err)
+		return err
+	}
+	transaction, err := policy.ParsePolicyTransaction(valmap[transactionKey])
+	if err != nil {
+		return err
+	}
+	if transaction == nil {
+		transaction = &policy.PolicyTransaction{}
+	}
+	transaction.TransactionID = rmr.NewTransactionID()
+	transaction.Operation = "DELETE"
+	transaction.RanNames = ranNames
+	message := rmr.Message{Version: rh.messageVersion}
+	rmrMessage, err := message.PolicyMessage(strconv.FormatInt((int64(policyTypeId)), 10), string(policyInstanceID), "", "DELETE", transaction)
+	if err != nil {
+		a1.Logger.Error("error : %v", err)
+		return err
+	}
+	for _, ranName := range ranNames {
+		msg := models.RmrMessage{
+			ID:               policyOutboxMessageID(policyTypeId, policyInstanceID, ranName),
+			MessageType:      int64(rmr.MessageTypeID(rmr.A1PolicyRequest)),
+			SubID:            int64(policyTypeId),
+			Payload:          rmrMessage,
+			RanName:          ranName,
+			PolicyTypeID:     int64(policyTypeId),
+			PolicyInstanceID: string(policyInstanceID),
+			Operation:        "DELETE",
+			TransactionID:    transaction.TransactionID,
+		}
+		if rh.sendRmrMessage(msg) {
+			a1.Logger.Debug("rmrSendToXapp : DELETE sent to removed RAN node %q", ranName)
+		} else {
+			a1.Logger.Error("rmrSendToXapp : DELETE to removed RAN node %q not sent, queued for retry", ranName)
+		}
+	}
 	return nil
 }
 
@@ -524,11 +720,13 @@ func (rh *Resthook) DeletePolicyType(policyTypeId models.PolicyTypeID) error {
 		return err
 	}
 
-	var keys [1]string
-	key := a1PolicyPrefix + strconv.FormatInt((int64(policyTypeId)), 10)
-	keys[0] = key
+	// the handlers recorded for the type, with their liveness, go with it
+	keys := []string{
+		a1PolicyPrefix + strconv.FormatInt((int64(policyTypeId)), 10),
+		a1TypeHandlerPrefix + strconv.FormatInt((int64(policyTypeId)), 10),
+	}
 	if len(policyinstances) == 0 {
-		err := rh.db.Remove(a1MediatorNs, keys[:])
+		err := rh.db.Remove(a1MediatorNs, keys)
 		if err != nil {
 			a1.Logger.Error("error in deleting policy type err: %v", err)
 			return err
@@ -593,6 +791,46 @@ func (rh *Resthook) getMetaData(policyTypeId models.PolicyTypeID, policyInstance
 	return instanceMetadataMap, nil
 }
 
+// validatePolicyInstanceState checks that the instance may move to the given
+// lifecycle state and returns its current metadata. Instances that do not exist
+// yet have no state.
+func (rh *Resthook) validatePolicyInstanceState(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID, state string) (string, error) {
+	instanceMetadataKey := a1InstanceMetadataPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID)
+	var keys [1]string
+	keys[0] = instanceMetadataKey
+	valmap, err := rh.db.Get(a1MediatorNs, keys[:])
+	if err != nil {
+		a1.Logger.Error("policy instance error : %v", err)
+		return "", err
+	}
+	metadata, _ := valmap[instanceMetadataKey].(string)
+	currentState := policy.InstanceMetadataState(metadata)
+	if err = policy.ValidateStateTransition(currentState, state); err != nil {
+		a1.Logger.Error("policy instance %d.%s can not move from %s to %s", policyTypeId, policyInstanceID, currentState, state)
+		return "", err
+	}
+	return metadata, nil
+}
+
+// droppedRanNames returns the RAN nodes of the previous metadata an update no
+// longer targets. An instance sent to every node has none.
+func droppedRanNames(previousMetadata string, ranNames []string) []string {
+	if len(ranNames) == 0 {
+		return nil
+	}
+	targets := map[string]bool{}
+	for _, ranName := range ranNames {
+		targets[ranName] = true
+	}
+	var dropped []string
+	for _, ranName := range policy.InstanceMetadataRanNames(previousMetadata) {
+		if !targets[ranName] {
+			dropped = append(dropped, ranName)
+		}
+	}
+	return dropped
+}
+
 func (rh *Resthook) getPolicyInstanceStatus(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID) (bool, error) {
 	instancehandlerKey := a1HandlerPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID)
 	var keys [1]string
@@ -610,6 +848,18 @@ func (rh *Resthook) getPolicyInstanceStatus(policyTypeId models.PolicyTypeID, po
 	return false, nil
 }
 
+func (rh *Resthook) getPolicyHandlerStatus(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID) (map[string]policy.HandlerStatus, error) {
+	handlerStatusKey := a1HandlerStatusPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID)
+	var keys [1]string
+	keys[0] = handlerStatusKey
+	resp, err := rh.db.Get(a1MediatorNs, keys[:])
+	if err != nil {
+		a1.Logger.Error("error in retrieving handler status err: %v", err)
+		return nil, err
+	}
+	return policy.ParseHandlerStatus(resp[handlerStatusKey])
+}
+
 func (rh *Resthook) GetPolicyInstanceStatus(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID) (*a1_mediator.A1ControllerGetPolicyInstanceStatusOKBody, error) {
 	err := rh.instanceValidity(policyTypeId, policyInstanceID)
 	policyInstanceStatus := a1_mediator.A1ControllerGetPolicyInstanceStatusOKBody{}
@@ -635,14 +885,197 @@ func (rh *Resthook) GetPolicyInstanceStatus(policyTypeId models.PolicyTypeID, po
 		//this error maps to 503 error but can be mapped to 500: internal error
 		return &policyInstanceStatus, err
 	}
-	enforced, err := rh.getPolicyInstanceStatus(policyTypeId, policyInstanceID)
-	if err != nil || (err == nil && !enforced) {
-		a1.Logger.Error("marshal error : %v", err)
+	instanceMetadataKey := a1InstanceMetadataPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID)
+	if instanceMetadata, ok := metadata[instanceMetadataKey].(string); ok {
+		policyInstanceStatus.InstanceState = policy.InstanceMetadataState(instanceMetadata)
+	}
+	schedule, err := rh.getPolicySchedule(policyTypeId, policyInstanceID)
+	if err != nil {
+		return &policyInstanceStatus, err
+	}
+	if schedule != nil {
+		policyInstanceStatus.ScheduleStatus = schedule.Status
+		policyInstanceStatus.ValidFrom = schedule.ValidFrom
+		policyInstanceStatus.ValidUntil = schedule.ValidUntil
+	}
+	status, err := rh.policyStatusBody(policyTypeId, policyInstanceID, policyInstanceStatus.InstanceState)
+	if err != nil {
 		return &policyInstanceStatus, err
 	}
-	policyInstanceStatus.EnforceStatus = "ENFORCED"
-	policyInstanceStatus.EnforceReason = ""
-	return &policyInstanceStatus, nil
+	status.ScheduleStatus = policyInstanceStatus.ScheduleStatus
+	status.ValidFrom = policyInstanceStatus.ValidFrom
+	status.ValidUntil = policyInstanceStatus.ValidUntil
+	return status, nil
+}
+
+// policyStatusBody reads the acknowledgement and handler statuses of the instance
+// and builds its status the way it is notified
+func (rh *Resthook) policyStatusBody(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID, instanceState string) (*a1_mediator.A1ControllerGetPolicyInstanceStatusOKBody, error) {
+	handlers, err := rh.getPolicyHandlerStatus(policyTypeId, policyInstanceID)
+	if err != nil {
+		return nil, err
+	}
+	ackStatus, err := rh.getPolicyAckStatus(policyTypeId, policyInstanceID)
+	if err != nil {
+		return nil, err
+	}
+	enforced, err := rh.getPolicyInstanceStatus(policyTypeId, policyInstanceID)
+	if err != nil {
+		return nil, err
+	}
+	status := policy.PolicyStatusBody(enforced, instanceState, ackStatus, handlers)
+	return &status, nil
+}
+
+// GetAllPolicyInstanceStatus returns the status of every instance of a policy type.
+// The metadata, handler statuses and schedule of all the instances are read with
+// a single SDL get instead of one round trip per instance.
+func (rh *Resthook) GetAllPolicyInstanceStatus(policyTypeId models.PolicyTypeID) ([]*models.PolicyInstanceStatus, error) {
+	a1.Logger.Debug("GetAllPolicyInstanceStatus")
+	err := rh.typeValidity(policyTypeId)
+	if err != nil {
+		return nil, err
+	}
+	policyinstances, err := rh.GetAllPolicyInstance(policyTypeId)
+	if err != nil {
+		a1.Logger.Error("error in retrieving policy. err: %v", err)
+		return nil, err
+	}
+	policyInstanceStatuses := []*models.PolicyInstanceStatus{}
+	if len(policyinstances) == 0 {
+		return policyInstanceStatuses, nil
+	}
+
+	keys := make([]string, 0, 4*len(policyinstances))
+	for _, policyInstanceID := range policyinstances {
+		suffix := strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID)
+		keys = append(keys, a1InstanceMetadataPrefix+suffix, a1HandlerPrefix+suffix, a1HandlerStatusPrefix+suffix, a1SchedulePrefix+suffix)
+	}
+	valmap, err := rh.db.Get(a1MediatorNs, keys)
+	if err != nil {
+		a1.Logger.Error("error in retrieving policy instance status err: %v", err)
+		return nil, err
+	}
+
+	for _, policyInstanceID := range policyinstances {
+		suffix := strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID)
+		policyInstanceStatus := &models.PolicyInstanceStatus{
+			PolicyInstanceID: string(policyInstanceID),
+			EnforceStatus:    "NOT_ENFORCED",
+			EnforceReason:    "OTHER_REASON",
+		}
+		if metadata, ok := valmap[a1InstanceMetadataPrefix+suffix].(string); ok {
+			policyInstanceStatus.CreatedAt = metadataCreatedAt(metadata)
+			policyInstanceStatus.InstanceState = policy.InstanceMetadataState(metadata)
+		}
+		if data, ok := valmap[a1SchedulePrefix+suffix].(string); ok {
+			var schedule policySchedule
+			if err := json.Unmarshal([]byte(data), &schedule); err == nil {
+				policyInstanceStatus.ScheduleStatus = schedule.Status
+				policyInstanceStatus.ValidFrom = schedule.ValidFrom
+				policyInstanceStatus.ValidUntil = schedule.ValidUntil
+			}
+		}
+		handlers, err := policy.ParseHandlerStatus(valmap[a1HandlerStatusPrefix+suffix])
+		if err != nil {
+			a1.Logger.Error("invalid handler status of policy instance %s : %v", suffix, err)
+		}
+		policyInstanceStatus.AggregateStatus = policy.AggregateHandlerStatus(handlers)
+		if valmap[a1HandlerPrefix+suffix] == "OK" {
+			policyInstanceStatus.EnforceStatus = "ENFORCED"
+			policyInstanceStatus.EnforceReason = ""
+		} else {
+			policyInstanceStatus.EnforceReason, policyInstanceStatus.EnforceDetail = policy.EnforceReason(handlers)
+		}
+		policyInstanceStatuses = append(policyInstanceStatuses, policyInstanceStatus)
+	}
+	return policyInstanceStatuses, nil
+}
+
+// GetPolicyStatusSummary counts the enforced and not enforced instances of every
+// policy type, using one SDL key scan and one batched get of the handler statuses
+func (rh *Resthook) GetPolicyStatusSummary() ([]*models.PolicyTypeStatusSummary, error) {
+	a1.Logger.Debug("GetPolicyStatusSummary")
+	keys, err := rh.db.GetAll(a1MediatorNs)
+	if err != nil {
+		a1.Logger.Error("error in retrieving policy. err: %v", err)
+		return nil, err
+	}
+
+	summaries := map[int64]*models.PolicyTypeStatusSummary{}
+	var policyTypeIds []int64
+	var handlerKeys []string
+	handlerTypes := map[string]int64{}
+	for _, key := range keys {
+		key = strings.Trim(key, " ")
+		if strings.HasPrefix(key, a1PolicyPrefix) {
+			policyTypeId, err := strconv.ParseInt(strings.TrimPrefix(key, a1PolicyPrefix), 10, 64)
+			if err != nil {
+				continue
+			}
+			if _, ok := summaries[policyTypeId]; !ok {
+				summaries[policyTypeId] = &models.PolicyTypeStatusSummary{PolicyTypeID: policyTypeId}
+				policyTypeIds = append(policyTypeIds, policyTypeId)
+			}
+		} else if strings.HasPrefix(key, a1InstancePrefix) {
+			ids := strings.SplitN(strings.TrimPrefix(key, a1InstancePrefix), ".", 2)
+			if len(ids) != 2 {
+				continue
+			}
+			policyTypeId, err := strconv.ParseInt(ids[0], 10, 64)
+			if err != nil {
+				continue
+			}
+			handlerKey := a1HandlerPrefix + ids[0] + "." + ids[1]
+			handlerKeys = append(handlerKeys, handlerKey)
+			handlerTypes[handlerKey] = policyTypeId
+		}
+	}
+
+	var valmap map[string]interface{}
+	if len(handlerKeys) > 0 {
+		valmap, err = rh.db.Get(a1MediatorNs, handlerKeys)
+		if err != nil {
+			a1.Logger.Error("error in retrieving policy instance status err: %v", err)
+			return nil, err
+		}
+	}
+	for _, handlerKey := range handlerKeys {
+		summary, ok := summaries[handlerTypes[handlerKey]]
+		if !ok {
+			continue
+		}
+		summary.Instances++
+		if valmap[handlerKey] == "OK" {
+			summary.Enforced++
+		} else {
+			summary.NotEnforced++
+		}
+	}
+
+	sort.Slice(policyTypeIds, func(i, j int) bool { return policyTypeIds[i] < policyTypeIds[j] })
+	policyTypeSummaries := []*models.PolicyTypeStatusSummary{}
+	for _, policyTypeId := range policyTypeIds {
+		policyTypeSummaries = append(policyTypeSummaries, summaries[policyTypeId])
+	}
+	return policyTypeSummaries, nil
+}
+
+// metadataCreatedAt extracts the creation time from the stored instance metadata,
+// which is a list for live instances and a single object for deleted ones
+func metadataCreatedAt(metadata string) string {
+	var metadataList []map[string]string
+	if err := json.Unmarshal([]byte(metadata), &metadataList); err == nil {
+		if len(metadataList) > 0 {
+			return metadataList[0]["created_at"]
+		}
+		return ""
+	}
+	var metadataMap map[string]string
+	if err := json.Unmarshal([]byte(metadata), &metadataMap); err == nil {
+		return metadataMap["created_at"]
+	}
+	return ""
 }
 
 func (rh *Resthook) storeDeletedPolicyInstanceMetadata(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID, creation_timestamp string) error {
@@ -653,7 +1086,7 @@ func (rh *Resthook) storeDeletedPolicyInstanceMetadata(policyTypeId models.Polic
 	a1.Logger.Debug("instanceMetadata Key : %+v", instanceMetadataKey)
 
 	var metadatajson interface{}
-	metadatajson = map[string]string{"created_at": creation_timestamp, "has_been_deleted": "True", "deleted_at": deleted_timestamp.Format("2006-01-02 15:04:05")}
+	metadatajson = map[string]string{"created_at": creation_timestamp, "has_been_deleted": "True", "deleted_at": deleted_timestamp.Format("2006-01-02 15:04:05"), "state": policy.InstanceStateDeleted}
 	a1.Logger.Debug("metadatajson to create : %+v", metadatajson)
 	deletedmetadata, err := json.Marshal(metadatajson)
 
@@ -696,6 +1129,18 @@ func (rh *Resthook) deleteNotificationDestination(policyTypeId models.PolicyType
 	return nil
 }
 
+func (rh *Resthook) deletePolicyInstanceStatus(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID) error {
+	suffix := strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID)
+	rh.ackTimers.cancel(a1AckPrefix + suffix)
+	keys := []string{a1HandlerPrefix + suffix, a1HandlerStatusPrefix + suffix, a1AckPrefix + suffix}
+	err := rh.db.Remove(a1MediatorNs, keys)
+	if err != nil {
+		a1.Logger.Error("error in deleting policy instance status err: %v", err)
+		return err
+	}
+	return nil
+}
+
 func (rh *Resthook) deleteMetadata(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID) error {
 	var keys [1]string
 	instanceMetadataKey := a1InstanceMetadataPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID)
@@ -710,7 +1155,10 @@ func (rh *Resthook) deleteMetadata(policyTypeId models.PolicyTypeID, policyInsta
 	return nil
 }
 
-func (rh *Resthook) DeletePolicyInstance(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID) error {
+// DeletePolicyInstance moves the instance to the DELETING state and asks the xApps
+// to remove it. The instance is removed once they all acknowledge the deletion or
+// the delete timeout expires, or right away when forced.
+func (rh *Resthook) DeletePolicyInstance(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID, force bool) error {
 	err := rh.instanceValidity(policyTypeId, policyInstanceID)
 	if err != nil {
 		a1.Logger.Error("policy instance error : %v", err)
@@ -729,6 +1177,13 @@ func (rh *Resthook) DeletePolicyInstance(policyTypeId models.PolicyTypeID, polic
 	creation_metadata := createdmetadata[instanceMetadataKey]
 	var metadata map[string]interface{}
 	creation_metadata_string := creation_metadata.(string)
+	if err = policy.ValidateStateTransition(policy.InstanceMetadataState(creation_metadata_string), policy.InstanceStateDeleting); err != nil {
+		a1.Logger.Error("policy instance %d.%s can not be deleted : %v", policyTypeId, policyInstanceID, err)
+		return err
+	}
+	if !force && rh.deleteTimeout > 0 {
+		return rh.startPolicyInstanceDelete(policyTypeId, policyInstanceID, creation_metadata_string)
+	}
 	creation_metadata_string = strings.TrimRight(creation_metadata_string, "]")
 	creation_metadata_string = strings.TrimLeft(creation_metadata_string, "[")
 	if err = json.Unmarshal([]byte(creation_metadata_string), &metadata); err != nil {
@@ -739,29 +1194,26 @@ func (rh *Resthook) DeletePolicyInstance(policyTypeId models.PolicyTypeID, polic
 	a1.Logger.Debug(" created metadata created_at %v", metadata["created_at"])
 	creation_timestamp := metadata["created_at"]
 
-	rh.deleteMetadata(policyTypeId, policyInstanceID)
+	// the DELETE is sent first, to the RAN nodes kept in the metadata of the instance
+	err = rh.sendPolicyRequest(policyTypeId, policyInstanceID, "", "DELETE", false)
 
-	rh.deleteInstancedata(policyTypeId, policyInstanceID)
+	rh.removePolicyInstance(policyTypeId, policyInstanceID, creation_timestamp.(string))
 
-	rh.deleteNotificationDestination(policyTypeId, policyInstanceID)
+	return err
+}
 
-	rh.storeDeletedPolicyInstanceMetadata(policyTypeId, policyInstanceID, creation_timestamp.(string))
+// removePolicyInstance removes every key of the instance, stops its timers and
+// keeps its deletion metadata
+func (rh *Resthook) removePolicyInstance(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID, creation_timestamp string) {
+	rh.deleteTimers.cancel(policyInstanceMetadataKey(policyTypeId, policyInstanceID))
+	rh.scheduleTimers.cancel(policyScheduleKey(policyTypeId, policyInstanceID))
+	rh.ackTimers.cancel(policyAckKey(policyTypeId, policyInstanceID))
 
-	message := rmr.Message{}
-	rmrMessage, err1 := message.PolicyMessage(strconv.FormatInt((int64(policyTypeId)), 10), string(policyInstanceID), "", "DELETE")
-	if err1 != nil {
-		a1.Logger.Error("error : %v", err1)
-		return err1
-	}
-	isSent := rh.iRmrSenderInst.RmrSendToXapp(rmrMessage, a1PolicyRequest, int(policyTypeId))
-	if isSent {
-		a1.Logger.Debug("rmrSendToXapp : message sent")
-	} else {
-		//TODO:if message not sent need to return error or just log it or retry sending
-		a1.Logger.Error("rmrSendToXapp : message not sent")
+	if err := rh.db.Remove(a1MediatorNs, policy.InstanceDataKeys(int(policyTypeId), string(policyInstanceID))); err != nil {
+		a1.Logger.Error("error in deleting policy instance err: %v", err)
 	}
 
-	return nil
+	rh.storeDeletedPolicyInstanceMetadata(policyTypeId, policyInstanceID, creation_timestamp)
 }
 
 func (rh *Resthook) DataDelivery(httpBody interface{}) error {
@@ -774,11 +1226,11 @@ func (rh *Resthook) DataDelivery(httpBody interface{}) error {
 		return err
 	}
 	a1.Logger.Debug("rmrSendToXapp :rmrMessage %+v", rmrMessage)
-	isSent := rh.iRmrSenderInst.RmrSendToXapp(rmrMessage, a1EIDataDelivery, rmr.DefaultSubId)
+	isSent := rh.sendRmrMessage(models.RmrMessage{MessageType: int64(rmr.MessageTypeID(rmr.A1EiDataDelivery)), SubID: rmr.DefaultSubId, Payload: rmrMessage})
 	if isSent {
 		a1.Logger.Debug("rmrSendToXapp : message sent")
 	} else {
-		a1.Logger.Error("rmrSendToXapp : message not sent")
+		a1.Logger.Error("rmrSendToXapp : message not sent, queued for retry")
 	}
 	return nil
 }
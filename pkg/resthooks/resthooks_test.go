@@ -24,6 +24,8 @@
 
 import (
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strconv"
 	"testing"
@@ -32,6 +34,8 @@
         "fmt"
 	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
 	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/models"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/policy"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/restapi/operations/a1_mediator"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -253,9 +257,7 @@ func TestGetAllPolicyIntances(t *testing.T) {
 func TestDeletePolicyType(t *testing.T) {
 
 	policyTypeId := models.PolicyTypeID(20001)
-	key := a1PolicyPrefix + strconv.FormatInt((int64(policyTypeId)), 10)
-	var keys [1]string
-	keys[0] = key
+	keys := []string{a1PolicyPrefix + "20001", a1TypeHandlerPrefix + "20001"}
 
 	//Setup Expectations
         sdlInst.On("GetAll", "A1m_ns").Return([]string{"a1.policy_instance.1006001.qos","a1.policy_instance.20005.123456","a1.policy_instance.20005.234567","a1.policy_type.1006001","a1.policy_type.20000","a1.policy_inst_metadata.1006001.qos",},nil).Once()
@@ -287,6 +289,15 @@ func TestGetPolicyInstanceStatus(t *testing.T) {
 	instancekeys[0] = instancekey
 	instancearr := []interface{}{instancekey, "OK"}
 	sdlInst.On("Get", a1MediatorNs, instancekeys[:]).Return(instancearr, nil)
+	var schedulekeys [1]string
+	schedulekeys[0] = a1SchedulePrefix + strconv.FormatInt(20001, 10) + "." + string(policyInstanceID)
+	sdlInst.On("Get", a1MediatorNs, schedulekeys[:]).Return(map[string]interface{}{}, nil)
+	var handlerstatuskeys [1]string
+	handlerstatuskeys[0] = a1HandlerStatusPrefix + strconv.FormatInt(20001, 10) + "." + string(policyInstanceID)
+	sdlInst.On("Get", a1MediatorNs, handlerstatuskeys[:]).Return(map[string]interface{}{}, nil)
+	var ackkeys [1]string
+	ackkeys[0] = a1AckPrefix + strconv.FormatInt(20001, 10) + "." + string(policyInstanceID)
+	sdlInst.On("Get", a1MediatorNs, ackkeys[:]).Return(map[string]interface{}{}, nil)
 	resp, errresp := rh.GetPolicyInstanceStatus(policyTypeId, policyInstanceID)
 
 	assert.Nil(t, errresp)
@@ -340,17 +351,13 @@ func TestDeletePolicyInstance(t *testing.T) {
 
 	sdlInst.On("Get", a1MediatorNs, instanceMetadataKeys[:]).Return(httpBody, nil)
 
-	sdlInst.On("Remove", a1MediatorNs, instanceMetadataKeys[:]).Return(nil)
-
-	var metadatainstancekeys [1]string
-	metadatainstancekeys[0] = instancekey
-
-	sdlInst.On("Remove", a1MediatorNs, metadatainstancekeys[:]).Return(nil)
+	instanceDataKeys := policy.InstanceDataKeys(20001, "123456")
+	sdlInst.On("Remove", a1MediatorNs, instanceDataKeys).Return(nil).Once()
 
 	metadatainstancekey := a1InstanceMetadataPrefix + strconv.FormatInt(20001, 10) + "." + string(policyInstanceID)
 	deleted_timestamp := time.Now()
 	var metadatajson interface{}
-	metadatajson = map[string]string{"created_at": "2022-11-02 10:30:20", "deleted_at": deleted_timestamp.Format("2006-01-02 15:04:05"), "has_been_deleted": "True"}
+	metadatajson = map[string]string{"created_at": "2022-11-02 10:30:20", "deleted_at": deleted_timestamp.Format("2006-01-02 15:04:05"), "has_been_deleted": "True", "state": policy.InstanceStateDeleted}
 	metadata, _ := json.Marshal(metadatajson)
 	metadatainstancearr := []interface{}{metadatainstancekey, string(metadata)}
 
@@ -359,11 +366,12 @@ func TestDeletePolicyInstance(t *testing.T) {
 	httpBodyString := `{"operation":"DELETE","payload":"","policy_instance_id":"123456","policy_type_id":"20001"}`
 
 	rmrSenderInst.On("RmrSendToXapp", httpBodyString, 20010, int(policyTypeId)).Return(true)
-	notificationDestinationkey := a1NotificationDestinationPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID)
-	var notificationDestinationkeys [1]string
-	notificationDestinationkeys[0] = notificationDestinationkey
-	sdlInst.On("Remove", a1MediatorNs, notificationDestinationkeys[:]).Return(nil)
-	errresp := rh.DeletePolicyInstance(policyTypeId, policyInstanceID)
+	transactionkeys := []string{a1TransactionPrefix + "20001.123456", a1InstanceMetadataPrefix + "20001.123456"}
+	sdlInst.On("Get", a1MediatorNs, transactionkeys).Return(map[string]interface{}{}, nil).Once()
+	sdlInst.On("Set", "A1m_ns", mock.MatchedBy(func(pairs []interface{}) bool {
+		return len(pairs) == 2 && pairs[0] == transactionkeys[0]
+	})).Return(nil).Once()
+	errresp := rh.DeletePolicyInstance(policyTypeId, policyInstanceID, false)
 
 	assert.Nil(t, errresp)
 	sdlInst.AssertExpectations(t)
@@ -566,12 +574,25 @@ func TestStorePolicyInstanceMetadataFail(t *testing.T) {
         policyInstanceID = ""
         var policyTypeId models.PolicyTypeID
         policyTypeId = 0
-	sdlInst.On("Set", "A1m_ns", mock.Anything).Return(errors.New("Some Error")).Once()
-        resp,err := rh.storePolicyInstanceMetadata(policyTypeId,policyInstanceID)
+	sdlInst.On("SetIfNotExists", "A1m_ns", a1InstanceMetadataPrefix+"0.", mock.Anything).Return(false, errors.New("Some Error")).Once()
+        resp,err := rh.storePolicyInstanceMetadata(policyTypeId,policyInstanceID,"",nil)
         assert.NotNil(t, err)
         assert.Equal(t, false, resp)
 }
 
+func TestStorePolicyInstanceMetadataConcurrentDelete(t *testing.T) {
+	instanceMetadataKey := a1InstanceMetadataPrefix + "20001.654333"
+	deletingMetadata := `[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"DELETING"}]`
+	store := memSdl{instanceMetadataKey: deletingMetadata}
+	metadatarh := createResthook(store, new(RmrSenderMock))
+
+	// the instance was deleted after its state got validated
+	_, err := metadatarh.storePolicyInstanceMetadata(20001, "654333", `[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"ENFORCED"}]`, nil)
+
+	assert.True(t, policy.IsInvalidStateTransition(err))
+	assert.Equal(t, deletingMetadata, store[instanceMetadataKey])
+}
+
 func TestStoreDeletedPolicyInstanceMetadataFail(t *testing.T) {
         var policyInstanceID models.PolicyInstanceID
         policyInstanceID = ""
@@ -660,9 +681,7 @@ func TestDeletePolicyTypeFail2(t *testing.T) {
 func TestDeletePolicyTypeFail3(t *testing.T) {
 
         policyTypeId := models.PolicyTypeID(20000)
-        key := a1PolicyPrefix + strconv.FormatInt((int64(policyTypeId)), 10)
-        var keys [1]string
-        keys[0] = key
+        keys := []string{a1PolicyPrefix + "20000", a1TypeHandlerPrefix + "20000"}
 
         //Setup Expectations
         sdlInst.On("GetAll", "A1m_ns").Return([]string{"a1.policy_instance.1006001.qos","a1.policy_instance.20005.123456","a1.policy_instance.20005.234567","a1.policy_type.1006001","a1.policy_type.20000","a1.policy_inst_metadata.1006001.qos",},nil).Once()
@@ -789,12 +808,11 @@ func TestCreatePolicyTypeInstance(t *testing.T) {
 	metadatainstancekey := a1InstanceMetadataPrefix + strconv.FormatInt(20001, 10) + "." + string(policyInstanceID)
 	creation_timestamp := time.Now()
 	var metadatajson []interface{}
-	metadatajson = append(metadatajson, map[string]string{"created_at": creation_timestamp.Format("2006-01-02 15:04:05"), "has_been_deleted": "False"})
+	metadatajson = append(metadatajson, map[string]string{"created_at": creation_timestamp.Format("2006-01-02 15:04:05"), "has_been_deleted": "False", "state": policy.InstanceStatePending})
 	metadata, _ := json.Marshal(metadatajson)
 	a1.Logger.Debug("Marshaled Metadata : %+v", string(metadata))
 	a1.Logger.Debug("metadatainstancekey   : %+v", metadatainstancekey)
-	metadatainstancearr := []interface{}{metadatainstancekey, string(metadata)}
-	sdlInst.On("Set", "A1m_ns", metadatainstancearr).Return(nil)
+	sdlInst.On("SetIfNotExists", a1MediatorNs, metadatainstancekey, mock.Anything, string(metadata)).Return(true, nil).Once()
 	sdlInst.On("SetIfNotExists", a1MediatorNs, instancekey, string(httpBody), string(data)).Return(true, nil)
         notificationDestinationkey := a1NotificationDestinationPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID)
         notificationDestination := "https://www.abc.com"
@@ -802,10 +820,21 @@ func TestCreatePolicyTypeInstance(t *testing.T) {
 	sdlInst.On("Set", "A1m_ns", notificationarr).Return(nil)
           
 	rmrSenderInst.On("RmrSendToXapp", "httpBodyString", 20010, int(policyTypeId)).Return(true)
-
-	errresp := rh.CreatePolicyInstance(policyTypeId, policyInstanceID, instancedata, notificationDestination)
+	sdlInst.On("Get", a1MediatorNs, []string{a1TypeHandlerPrefix + "20001"}).Return(map[string]interface{}{}, nil).Once()
+	sdlInst.On("Remove", a1MediatorNs, []string{a1SchedulePrefix + "20001.123456"}).Return(nil).Once()
+	// the instance already exists, the statuses of its previous revision are cleared
+	handlerkeys := []string{a1HandlerPrefix + "20001.123456", a1HandlerStatusPrefix + "20001.123456", a1AckPrefix + "20001.123456"}
+	sdlInst.On("Remove", a1MediatorNs, handlerkeys).Return(nil).Once()
+	transactionkeys := []string{a1TransactionPrefix + "20001.123456", a1InstanceMetadataPrefix + "20001.123456"}
+	sdlInst.On("Get", a1MediatorNs, transactionkeys).Return(map[string]interface{}{}, nil).Once()
+	sdlInst.On("Set", "A1m_ns", mock.MatchedBy(func(pairs []interface{}) bool {
+		return len(pairs) == 2 && pairs[0] == transactionkeys[0]
+	})).Return(nil).Once()
+
+	errresp := rh.CreatePolicyInstance(policyTypeId, policyInstanceID, instancedata, notificationDestination, "", "", nil)
 
 	assert.Nil(t, errresp)
+	sdlInst.AssertCalled(t, "Remove", a1MediatorNs, handlerkeys)
 }
 
 func TestCreatePolicyTypeInstance2(t *testing.T) {
@@ -825,17 +854,19 @@ func TestCreatePolicyTypeInstance2(t *testing.T) {
 	a1.Logger.Debug("instancekey   : %+v", instancekey)
 	instancearr := []interface{}{instancekey, string(data)}
 	sdlInst.On("Set", "A1m_ns", instancearr).Return(nil)
+	var metadatakeys [1]string
+	metadatakeys[0] = a1InstanceMetadataPrefix + strconv.FormatInt(20001, 10) + "." + string(policyInstanceID)
+	sdlInst.On("Get", a1MediatorNs, metadatakeys[:]).Return(map[string]interface{}{}, nil).Once()
         sdlInst.On("Get", "A1m_ns", mock.Anything).Return(instancearr, nil).Once()
 
 	metadatainstancekey := a1InstanceMetadataPrefix + strconv.FormatInt(20001, 10) + "." + string(policyInstanceID)
 	creation_timestamp := time.Now()
 	var metadatajson []interface{}
-	metadatajson = append(metadatajson, map[string]string{"created_at": creation_timestamp.Format("2006-01-02 15:04:05"), "has_been_deleted": "False"})
+	metadatajson = append(metadatajson, map[string]string{"created_at": creation_timestamp.Format("2006-01-02 15:04:05"), "has_been_deleted": "False", "state": policy.InstanceStatePending})
 	metadata, _ := json.Marshal(metadatajson)
 	a1.Logger.Debug("Marshaled Metadata : %+v", string(metadata))
 	a1.Logger.Debug("metadatainstancekey   : %+v", metadatainstancekey)
-	metadatainstancearr := []interface{}{metadatainstancekey, string(metadata)}
-	sdlInst.On("Set", "A1m_ns", metadatainstancearr).Return(nil)
+	sdlInst.On("SetIfNotExists", a1MediatorNs, metadatainstancekey, string(metadata)).Return(true, nil).Once()
 	sdlInst.On("SetIfNotExists", a1MediatorNs, instancekey, string(httpBody), string(data)).Return(true, nil)
         notificationDestinationkey := a1NotificationDestinationPrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID)
         notificationDestination := "https://www.abc.com"
@@ -843,8 +874,15 @@ func TestCreatePolicyTypeInstance2(t *testing.T) {
 	sdlInst.On("Set", "A1m_ns", notificationarr).Return(nil)
           
 	rmrSenderInst.On("RmrSendToXapp", "httpBodyString", 20010, int(policyTypeId)).Return(true)
+	sdlInst.On("Get", a1MediatorNs, []string{a1TypeHandlerPrefix + "20001"}).Return(map[string]interface{}{}, nil).Once()
+	sdlInst.On("Remove", a1MediatorNs, []string{a1SchedulePrefix + "20001.123"}).Return(nil).Once()
+	transactionkeys := []string{a1TransactionPrefix + "20001.123", a1InstanceMetadataPrefix + "20001.123"}
+	sdlInst.On("Get", a1MediatorNs, transactionkeys).Return(map[string]interface{}{}, nil).Once()
+	sdlInst.On("Set", "A1m_ns", mock.MatchedBy(func(pairs []interface{}) bool {
+		return len(pairs) == 2 && pairs[0] == transactionkeys[0]
+	})).Return(nil).Once()
 
-	errresp := rh.CreatePolicyInstance(policyTypeId, policyInstanceID, instancedata, notificationDestination)
+	errresp := rh.CreatePolicyInstance(policyTypeId, policyInstanceID, instancedata, notificationDestination, "", "", nil)
 
 	assert.Nil(t, errresp)
 }
@@ -854,10 +892,428 @@ func TestCreatePolicyTypeInstanceFail(t *testing.T) {
 	policyInstanceID = "123456"
 	var policyTypeId models.PolicyTypeID
 	policyTypeId = 20001
-        errresp := rh.CreatePolicyInstance(policyTypeId, policyInstanceID, "", "")
+        errresp := rh.CreatePolicyInstance(policyTypeId, policyInstanceID, "", "", "", "", nil)
         assert.NotNil(t, errresp)
 }
 
+func TestGetAllPolicyInstanceStatus(t *testing.T) {
+	policyTypeId := models.PolicyTypeID(20001)
+	var typekeys [1]string
+	typekeys[0] = a1PolicyPrefix + strconv.FormatInt((int64(policyTypeId)), 10)
+	sdlInst.On("Get", a1MediatorNs, typekeys[:]).Return(map[string]interface{}{}, nil)
+	sdlInst.On("GetAll", "A1m_ns").Return([]string{"a1.policy_type.20001", "a1.policy_instance.20001.123456"}, nil).Once()
+	keys := []string{"a1.policy_inst_metadata.20001.123456", "a1.policy_handler.20001.123456", "a1.policy_handler_status.20001.123456", "a1.policy_schedule.20001.123456"}
+	sdlInst.On("Get", a1MediatorNs, keys).Return(map[string]interface{}{}, nil).Once()
+
+	resp, err := rh.GetAllPolicyInstanceStatus(policyTypeId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(resp))
+	assert.Equal(t, "123456", resp[0].PolicyInstanceID)
+	assert.Equal(t, "NOT_ENFORCED", resp[0].EnforceStatus)
+	assert.Equal(t, "OTHER_REASON", resp[0].EnforceReason)
+	assert.Equal(t, "", resp[0].AggregateStatus)
+	assert.Equal(t, "2022-11-02 10:30:20", resp[0].CreatedAt)
+}
+
+func TestGetPolicyStatusSummary(t *testing.T) {
+	sdlInst.On("GetAll", "A1m_ns").Return([]string{"a1.policy_type.20001", "a1.policy_type.20002", "a1.policy_instance.20001.123456"}, nil).Once()
+	keys := []string{"a1.policy_handler.20001.123456"}
+	sdlInst.On("Get", a1MediatorNs, keys).Return(map[string]interface{}{}, nil).Once()
+
+	resp, err := rh.GetPolicyStatusSummary()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(resp))
+	assert.Equal(t, int64(20001), resp[0].PolicyTypeID)
+	assert.Equal(t, int64(1), resp[0].Instances)
+	assert.Equal(t, int64(1), resp[0].NotEnforced)
+	assert.Equal(t, int64(0), resp[1].Instances)
+}
+
+func TestMetadataCreatedAt(t *testing.T) {
+	assert.Equal(t, "2022-11-02 10:30:20", metadataCreatedAt(`[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False"}]`))
+	assert.Equal(t, "2022-11-02 10:30:20", metadataCreatedAt(`{"created_at":"2022-11-02 10:30:20","has_been_deleted":"True"}`))
+	assert.Equal(t, "", metadataCreatedAt("invalid"))
+}
+
+func TestCheckPolicyAck(t *testing.T) {
+	notified := make(chan a1_mediator.A1ControllerGetPolicyInstanceStatusOKBody, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var status a1_mediator.A1ControllerGetPolicyInstanceStatusOKBody
+		json.NewDecoder(r.Body).Decode(&status)
+		notified <- status
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	store := memSdl{
+		a1AckPrefix + "20001.654321":                     ackPending,
+		a1InstanceMetadataPrefix + "20001.654321":        `[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"PENDING"}]`,
+		a1NotificationDestinationPrefix + "20001.654321": server.URL,
+	}
+	ackrh := createResthook(store, rmrSenderInst)
+
+	ackrh.checkPolicyAck(models.PolicyTypeID(20001), models.PolicyInstanceID("654321"))
+
+	assert.Equal(t, ackNotAcknowledged, store[a1AckPrefix+"20001.654321"])
+	assert.Equal(t, policy.InstanceStateNotEnforced, policy.InstanceMetadataState(store[a1InstanceMetadataPrefix+"20001.654321"].(string)))
+	select {
+	case status := <-notified:
+		assert.Equal(t, ackNotAcknowledged, status.AckStatus)
+		assert.Equal(t, policy.InstanceStateNotEnforced, status.InstanceState)
+		assert.Equal(t, "NOT_ENFORCED", status.EnforceStatus)
+		assert.Equal(t, "OTHER_REASON", status.EnforceReason)
+	default:
+		t.Error("policy status notification not sent")
+	}
+}
+
+func TestTrackPolicyAck(t *testing.T) {
+	policyTypeId := models.PolicyTypeID(20001)
+	policyInstanceID := models.PolicyInstanceID("654322")
+	ackKey := a1AckPrefix + "20001.654322"
+	store := memSdl{ackKey: "ACKNOWLEDGED"}
+	ackrh := createResthook(store, new(RmrSenderMock))
+	ackrh.ackTimeout = time.Hour
+
+	previous, tracked := ackrh.trackPolicyAck(policyTypeId, policyInstanceID)
+
+	assert.True(t, tracked)
+	assert.Equal(t, "ACKNOWLEDGED", previous)
+	assert.Equal(t, ackPending, store[ackKey])
+
+	ackrh.untrackPolicyAck(policyTypeId, policyInstanceID, previous)
+
+	assert.Equal(t, "ACKNOWLEDGED", store[ackKey])
+	assert.False(t, ackrh.ackTimers.cancel(ackKey))
+}
+
+func TestStartPolicyInstanceDelete(t *testing.T) {
+	policyTypeId := models.PolicyTypeID(20001)
+	policyInstanceID := models.PolicyInstanceID("654324")
+	instanceMetadataKey := a1InstanceMetadataPrefix + "20001.654324"
+	rh.deleteTimeout = time.Hour
+	defer func() { rh.deleteTimeout = 0 }()
+	deletingMetadata := `[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"DELETING"}]`
+	enforcedMetadata := `[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"ENFORCED"}]`
+	sdlInst.On("SetIfNotExists", "A1m_ns", instanceMetadataKey, enforcedMetadata, deletingMetadata).Return(true, nil).Once()
+	sdlInst.On("Remove", a1MediatorNs, []string{a1SchedulePrefix + "20001.654324"}).Return(nil).Once()
+	transactionkeys := []string{a1TransactionPrefix + "20001.654324", a1InstanceMetadataPrefix + "20001.654324"}
+	sdlInst.On("Get", a1MediatorNs, transactionkeys).Return(map[string]interface{}{}, nil).Once()
+	sdlInst.On("Set", "A1m_ns", mock.MatchedBy(func(pairs []interface{}) bool {
+		return len(pairs) == 2 && pairs[0] == transactionkeys[0]
+	})).Return(nil).Once()
+
+	err := rh.startPolicyInstanceDelete(policyTypeId, policyInstanceID, enforcedMetadata)
+
+	assert.Nil(t, err)
+	assert.True(t, rh.deleteTimers.cancel(instanceMetadataKey))
+}
+
+func TestTimerSetRemovesFiredTimers(t *testing.T) {
+	timers := newTimerSet()
+	fired := make(chan struct{})
+	timers.add("a1.policy_ack.20001.654334", time.Now(), func() { close(fired) })
+	timers.add("a1.policy_ack.20001.654334", time.Now().Add(time.Hour), func() {})
+	<-fired
+	// the later timer of the key survives the fired one
+	timers.mutex.Lock()
+	assert.Len(t, timers.timers["a1.policy_ack.20001.654334"], 1)
+	timers.mutex.Unlock()
+	assert.True(t, timers.cancel("a1.policy_ack.20001.654334"))
+
+	fired = make(chan struct{})
+	timers.add("a1.policy_ack.20001.654335", time.Now(), func() { close(fired) })
+	<-fired
+	timers.mutex.Lock()
+	assert.NotContains(t, timers.timers, "a1.policy_ack.20001.654335")
+	timers.mutex.Unlock()
+	assert.False(t, timers.cancel("a1.policy_ack.20001.654335"))
+}
+
+func TestCheckPolicyInstanceDelete(t *testing.T) {
+	policyTypeId := models.PolicyTypeID(20001)
+	policyInstanceID := models.PolicyInstanceID("654323")
+	suffix := "20001.654323"
+	sdlInst.On("Get", a1MediatorNs, []string{a1InstanceMetadataPrefix + suffix, a1NotificationDestinationPrefix + suffix}).Return(map[string]interface{}{}, nil).Once()
+	instanceDataKeys := policy.InstanceDataKeys(20001, "654323")
+	sdlInst.On("Remove", a1MediatorNs, instanceDataKeys).Return(nil).Once()
+	sdlInst.On("Set", "A1m_ns", mock.Anything).Return(nil).Once()
+
+	rh.checkPolicyInstanceDelete(policyTypeId, policyInstanceID)
+
+	sdlInst.AssertCalled(t, "Remove", a1MediatorNs, instanceDataKeys)
+}
+
+func TestNewPolicyTransaction(t *testing.T) {
+	policyTypeId := models.PolicyTypeID(20001)
+	policyInstanceID := models.PolicyInstanceID("654325")
+	transactionKey := a1TransactionPrefix + "20001.654325"
+	sdlInst.On("Get", a1MediatorNs, []string{transactionKey, a1InstanceMetadataPrefix + "20001.654325"}).Return(map[string]interface{}{}, nil).Times(3)
+	sdlInst.On("Set", "A1m_ns", mock.MatchedBy(func(pairs []interface{}) bool {
+		return len(pairs) == 2 && pairs[0] == transactionKey
+	})).Return(nil).Times(3)
+
+	transaction, err := rh.newPolicyTransaction(policyTypeId, policyInstanceID, "UPDATE", true)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), transaction.Revision)
+	assert.Equal(t, int64(8), transaction.Sequence)
+	assert.Equal(t, "UPDATE", transaction.Operation)
+	assert.Equal(t, transaction.SentAt, transaction.UpdatedAt)
+	assert.Equal(t, 16, len(transaction.TransactionID))
+	assert.NotEqual(t, "0123456789abcdef", transaction.TransactionID)
+
+	transaction, err = rh.newPolicyTransaction(policyTypeId, policyInstanceID, "DELETE", false)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), transaction.Revision)
+	assert.Equal(t, int64(8), transaction.Sequence)
+	assert.Equal(t, "2022-11-02 10:30:20", transaction.UpdatedAt)
+	assert.Equal(t, "2022-11-02 10:30:20", transaction.CreatedAt)
+
+	// a resync sends the instance again without changing its body
+	transaction, err = rh.newPolicyTransaction(policyTypeId, policyInstanceID, "CREATE", false)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), transaction.Revision)
+	assert.Equal(t, int64(8), transaction.Sequence)
+}
+
+func TestSendPolicyRequestToRanNodes(t *testing.T) {
+	rmrSender := new(RmrSenderMock)
+	store := memSdl{
+		a1InstanceMetadataPrefix + "20001.654326": `[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"PENDING","ran_names":["gnb_001","gnb_002"]}]`,
+	}
+	noderh := createResthook(store, rmrSender)
+	rmrSender.On("RmrSendToNode", mock.Anything, 20010, 20001, "gnb_001", mock.Anything).Return(true).Once()
+	rmrSender.On("RmrSendToNode", mock.Anything, 20010, 20001, "gnb_002", mock.Anything).Return(true).Once()
+
+	err := noderh.sendPolicyRequest(models.PolicyTypeID(20001), models.PolicyInstanceID("654326"), `{"enforce":true}`, "CREATE", true)
+
+	assert.Nil(t, err)
+	transaction, _ := policy.ParsePolicyTransaction(store[a1TransactionPrefix+"20001.654326"])
+	assert.Equal(t, []string{"gnb_001", "gnb_002"}, transaction.RanNames)
+	rmrSender.AssertExpectations(t)
+	rmrSender.AssertCalled(t, "RmrSendToNode", mock.Anything, 20010, 20001, "gnb_001", transaction.TransactionID)
+	rmrSender.AssertNotCalled(t, "RmrSendToXapp", mock.Anything, mock.Anything, mock.Anything)
+
+	// an update no longer targeting gnb_002 deletes the instance there
+	store[a1PolicyPrefix+"20001"] = `{"policy_type_id":20001,"name":"test","description":"test","create_schema":{"type":"object"}}`
+	store[a1InstancePrefix+"20001.654326"] = `{"enforce":true}`
+	var operations = map[string]string{}
+	rmrSender.On("RmrSendToNode", mock.Anything, 20010, 20001, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		var request map[string]interface{}
+		assert.Nil(t, json.Unmarshal([]byte(args.String(0)), &request))
+		operations[args.String(3)] = request["operation"].(string)
+	}).Return(true)
+
+	err = noderh.CreatePolicyInstance(models.PolicyTypeID(20001), models.PolicyInstanceID("654326"), map[string]interface{}{"enforce": false}, "", "", "", []string{"gnb_001"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"gnb_001": "UPDATE", "gnb_002": "DELETE"}, operations)
+}
+
+func TestForceDeletePolicyInstanceToRanNodes(t *testing.T) {
+	store := memSdl{}
+	rmrSender := new(RmrSenderMock)
+	noderh := createResthook(store, rmrSender)
+	store[a1PolicyPrefix+"20001"] = `{"policy_type_id":20001}`
+	store[a1InstancePrefix+"20001.654329"] = `{"enforce":true}`
+	store[a1InstanceMetadataPrefix+"20001.654329"] = `[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"ENFORCED","ran_names":["gnb_001","gnb_002"]}]`
+	rmrSender.On("RmrSendToNode", mock.Anything, 20010, 20001, "gnb_001", mock.Anything).Return(true).Once()
+	rmrSender.On("RmrSendToNode", mock.Anything, 20010, 20001, "gnb_002", mock.Anything).Return(true).Once()
+
+	err := noderh.DeletePolicyInstance(models.PolicyTypeID(20001), models.PolicyInstanceID("654329"), true)
+
+	assert.Nil(t, err)
+	rmrSender.AssertExpectations(t)
+	assert.Equal(t, policy.InstanceStateDeleted, policy.InstanceMetadataState(store[a1InstanceMetadataPrefix+"20001.654329"].(string)))
+	assert.NotContains(t, store, a1InstancePrefix+"20001.654329")
+	assert.NotContains(t, store, a1TransactionPrefix+"20001.654329")
+}
+
+func TestGetPolicyTypeHandlers(t *testing.T) {
+	policyTypeId := models.PolicyTypeID(20002)
+	sdlInst.On("Get", a1MediatorNs, []string{a1PolicyPrefix + "20002"}).Return(map[string]interface{}{}, nil).Once()
+	sdlInst.On("Get", a1MediatorNs, []string{a1TypeHandlerPrefix + "20002"}).Return(map[string]interface{}{}, nil).Once()
+
+	resp, err := rh.GetPolicyTypeHandlers(policyTypeId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(resp))
+	assert.Equal(t, "xapp1", resp[0].HandlerID)
+	assert.Equal(t, "OK", resp[0].LastStatus)
+}
+
+func TestRmrRetryDelay(t *testing.T) {
+	retry := rmrRetryPolicy{maxAttempts: 5, backoff: time.Second, maxBackoff: 5 * time.Second}
+	assert.Equal(t, time.Second, retry.delay(1))
+	assert.Equal(t, 4*time.Second, retry.delay(3))
+	assert.Equal(t, 5*time.Second, retry.delay(10))
+}
+
+func TestSendRmrMessageRetry(t *testing.T) {
+	rmrSender := new(RmrSenderMock)
+	retryrh := createResthook(sdlInst, rmrSender)
+	retryrh.rmrRetry = rmrRetryPolicy{maxAttempts: 2, backoff: time.Hour, maxBackoff: time.Hour}
+	httpBodyString := `{"ei_job_id":"1","payload":"payload"}`
+	rmrSender.On("RmrSendToXapp", httpBodyString, 20017, -1).Return(false)
+	sdlInst.On("Set", "A1m_ns", mock.MatchedBy(func(pairs []interface{}) bool {
+		key, _ := pairs[0].(string)
+		return len(pairs) == 2 && key[:len(a1OutboxPrefix)] == a1OutboxPrefix
+	})).Return(nil).Once()
+
+	assert.False(t, retryrh.sendRmrMessage(models.RmrMessage{MessageType: 20017, SubID: -1, Payload: httpBodyString}))
+	assert.Equal(t, 1, len(retryrh.outbox.timers))
+
+	msg := &models.RmrMessage{ID: "2", MessageType: 20017, SubID: -1, Payload: httpBodyString, Attempts: 1}
+	sdlInst.On("Set", "A1m_ns", mock.MatchedBy(func(pairs []interface{}) bool {
+		return len(pairs) == 2 && pairs[0] == a1DeadLetterPrefix+"2"
+	})).Return(nil).Once()
+	sdlInst.On("Remove", a1MediatorNs, []string{a1OutboxPrefix + "2"}).Return(nil).Once()
+	retryrh.resendRmrMessage(msg)
+	assert.Equal(t, int64(2), msg.Attempts)
+	sdlInst.AssertCalled(t, "Remove", a1MediatorNs, []string{a1OutboxPrefix + "2"})
+}
+
+func TestPolicyRmrMessageOutbox(t *testing.T) {
+	rmrSender := new(RmrSenderMock)
+	store := memSdl{
+		a1InstanceMetadataPrefix + "20001.654331": `[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"PENDING","ran_names":["gnb_001"]}]`,
+	}
+	outboxrh := createResthook(store, rmrSender)
+	outboxrh.rmrRetry = rmrRetryPolicy{maxAttempts: 5, backoff: time.Hour, maxBackoff: time.Hour}
+	outboxrh.ackTimeout = time.Hour
+	outboxKey := a1OutboxPrefix + "20001.654331/gnb_001"
+	rmrSender.On("RmrSendToNode", mock.Anything, 20010, 20001, "gnb_001", mock.Anything).Return(false).Twice()
+
+	outboxrh.sendPolicyRequest(models.PolicyTypeID(20001), models.PolicyInstanceID("654331"), `{"enforce":true}`, "CREATE", true)
+	outboxrh.sendPolicyRequest(models.PolicyTypeID(20001), models.PolicyInstanceID("654331"), `{"enforce":false}`, "UPDATE", true)
+
+	// the UPDATE replaced the queued CREATE
+	assert.Equal(t, 1, len(outboxrh.outbox.timers))
+	var msg models.RmrMessage
+	assert.Nil(t, json.Unmarshal([]byte(store[outboxKey].(string)), &msg))
+	assert.Equal(t, "UPDATE", msg.Operation)
+	assert.NotContains(t, store, a1AckPrefix+"20001.654331")
+
+	rmrSender.On("RmrSendToNode", mock.Anything, 20010, 20001, "gnb_001", mock.Anything).Return(true).Once()
+	outboxrh.resendRmrMessage(&msg)
+
+	assert.NotContains(t, store, outboxKey)
+	assert.Equal(t, ackPending, store[a1AckPrefix+"20001.654331"])
+	outboxrh.ackTimers.cancel(policyAckKey(models.PolicyTypeID(20001), models.PolicyInstanceID("654331")))
+}
+
+func TestSendPolicyRequestSynchronousResponse(t *testing.T) {
+	rmrSender := new(RmrSenderMock)
+	metadataKey := a1InstanceMetadataPrefix + "20001.654332"
+	ackKey := a1AckPrefix + "20001.654332"
+	store := memSdl{
+		metadataKey: `[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"PENDING","ran_names":["gnb_001"]}]`,
+	}
+	syncrh := createResthook(store, rmrSender)
+	syncrh.ackTimeout = 10 * time.Millisecond
+	// the response is handled before the send returns
+	rmrSender.On("RmrSendToNode", mock.Anything, 20010, 20001, "gnb_001", mock.Anything).Run(func(args mock.Arguments) {
+		store[ackKey] = "ACKNOWLEDGED"
+		store[metadataKey] = `[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"ENFORCED","ran_names":["gnb_001"]}]`
+	}).Return(true).Once()
+
+	err := syncrh.sendPolicyRequest(models.PolicyTypeID(20001), models.PolicyInstanceID("654332"), `{"enforce":true}`, "CREATE", true)
+
+	assert.Nil(t, err)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, "ACKNOWLEDGED", store[ackKey])
+	assert.Equal(t, policy.InstanceStateEnforced, policy.InstanceMetadataState(store[metadataKey].(string)))
+	rmrSender.AssertExpectations(t)
+}
+
+func TestGetRmrDeadLetters(t *testing.T) {
+	sdlInst.On("GetAll", "A1m_ns").Return([]string{"a1.policy_type.20001", "a1.rmr_dead_letter.1"}, nil).Once()
+	sdlInst.On("Get", a1MediatorNs, []string{"a1.rmr_dead_letter.1"}).Return(map[string]interface{}{}, nil).Once()
+
+	resp, err := rh.GetRmrDeadLetters()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(resp))
+	assert.Equal(t, "1", resp[0].ID)
+	assert.Equal(t, int64(5), resp[0].Attempts)
+}
+
+func TestGetResyncInstances(t *testing.T) {
+	policyTypeId := models.PolicyTypeID(20001)
+	sdlInst.On("GetAll", "A1m_ns").Return([]string{"a1.policy_type.20001", "a1.policy_instance.20001.123456", "a1.policy_instance.20002.1"}, nil).Once()
+	keys := []string{"a1.policy_instance.20001.123456", "a1.policy_inst_metadata.20001.123456", "a1.policy_schedule.20001.123456"}
+	sdlInst.On("Get", a1MediatorNs, keys).Return(map[string]interface{}{}, nil).Once()
+
+	instances, err := rh.getResyncInstances(&policyTypeId)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(instances))
+	assert.Equal(t, models.PolicyInstanceID("123456"), instances[0].policyInstanceID)
+	assert.Equal(t, `{"enforce":true,"window_length":20,"blocking_rate":20,"trigger_threshold":10}`, instances[0].payload)
+}
+
+func TestResyncPolicyInstancesRereadsInstances(t *testing.T) {
+	rmrSender := new(RmrSenderMock)
+	store := memSdl{
+		a1InstancePrefix + "20001.654334":         `{"enforce":false}`,
+		a1InstanceMetadataPrefix + "20001.654334": `[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"ENFORCED","ran_names":["gnb_001"]}]`,
+		a1InstancePrefix + "20001.654335":         `{"enforce":true}`,
+		a1InstanceMetadataPrefix + "20001.654335": `[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"DELETING","ran_names":["gnb_002"]}]`,
+	}
+	resyncrh := createResthook(store, rmrSender)
+	resyncrh.ackTimeout = time.Hour
+	// the first instance got updated, the second is being deleted and the third
+	// is gone since they were listed
+	instances := []resyncInstance{
+		{policyTypeId: 20001, policyInstanceID: "654334", payload: `{"enforce":true}`},
+		{policyTypeId: 20001, policyInstanceID: "654335", payload: `{"enforce":true}`},
+		{policyTypeId: 20001, policyInstanceID: "654336", payload: `{"enforce":true}`},
+	}
+	sent := mock.MatchedBy(func(body string) bool {
+		var message map[string]interface{}
+		return json.Unmarshal([]byte(body), &message) == nil && message["payload"] == `{"enforce":false}`
+	})
+	rmrSender.On("RmrSendToNode", sent, 20010, 20001, "gnb_001", mock.Anything).Return(true).Once()
+
+	resyncrh.resyncPolicyInstances(instances)
+
+	rmrSender.AssertExpectations(t)
+	assert.NotContains(t, store, a1TransactionPrefix+"20001.654335")
+	assert.NotContains(t, store, a1TransactionPrefix+"20001.654336")
+	resyncrh.ackTimers.cancel(a1AckPrefix + "20001.654334")
+}
+
+func TestDeletePolicySchedule(t *testing.T) {
+	scheduleKey := a1SchedulePrefix + "20001.654327"
+	sdlInst.On("Remove", a1MediatorNs, []string{scheduleKey}).Return(nil).Once()
+
+	// the window started and has no end, no timer is left for it
+	assert.Nil(t, rh.deletePolicySchedule(models.PolicyTypeID(20001), models.PolicyInstanceID("654327")))
+
+	sdlInst.AssertCalled(t, "Remove", a1MediatorNs, []string{scheduleKey})
+}
+
+func TestParseValidityWindow(t *testing.T) {
+	validFrom := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	validUntil := time.Now().Add(2 * time.Hour).UTC().Format(time.RFC3339)
+	from, until, err := parseValidityWindow(validFrom, validUntil)
+	assert.Nil(t, err)
+	assert.True(t, until.After(from))
+}
+
+func TestParseValidityWindowFail(t *testing.T) {
+	validFrom := time.Now().Add(2 * time.Hour).UTC().Format(time.RFC3339)
+	validUntil := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	_, _, err := parseValidityWindow(validFrom, validUntil)
+	assert.True(t, rh.IsValidityWindowInvalid(err))
+	_, _, err = parseValidityWindow("not a timestamp", "")
+	assert.True(t, rh.IsValidityWindowInvalid(err))
+}
+
 type SdlMock struct {
 	mock.Mock
 }
@@ -879,6 +1335,18 @@ func (s *SdlMock) Get(ns string, keys []string) (map[string]interface{}, error)
 	} else if keys[0] == "a1.policy_type.20001" {
 		policySchemaString = `{"create_schema":{"$schema":"http://json-schema.org/draft-07/schema#","properties":{"additionalProperties":false,"blocking_rate":{"default":10,"description":"% Connections to block","maximum":1001,"minimum":1,"type":"number"},"enforce":{"default":"true","type":"boolean"},"window_length":{"default":1,"description":"Sliding window length (in minutes)","maximum":60,"minimum":1,"type":"integer"}},"type":"object"},"description":"various parameters to control admission of dual connection","name":"admission_control_policy_mine","policy_type_id":20001}`
 		key = a1PolicyPrefix + strconv.FormatInt((20001), 10)
+	} else if keys[0] == "a1.rmr_dead_letter.1" {
+		policySchemaString = `{"id":"1","messageType":20017,"subId":-1,"payload":"payload","attempts":5,"createdAt":"2022-11-02T10:30:20Z"}`
+		key = a1DeadLetterPrefix + "1"
+	} else if keys[0] == "a1.policy_type_handler.20002" {
+		policySchemaString = `{"xapp1":{"first_seen":"2022-11-02 10:30:20","last_seen":"2022-11-02 10:30:21","last_status":"OK"}}`
+		key = a1TypeHandlerPrefix + "20002"
+	} else if keys[0] == "a1.policy_transaction.20001.654325" {
+		policySchemaString = `{"transaction_id":"0123456789abcdef","revision":3,"sequence":7,"operation":"CREATE","created_at":"2022-11-02 10:30:20","updated_at":"2022-11-02 10:30:20","sent_at":"2022-11-02 10:30:20"}`
+		key = a1TransactionPrefix + "20001.654325"
+	} else if keys[0] == "a1.policy_inst_metadata.20001.654323" {
+		policySchemaString = `[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"DELETING"}]`
+		key = a1InstanceMetadataPrefix + strconv.FormatInt(20001, 10) + "." + "654323"
 	} else if keys[0] == "a1.policy_inst_metadata.20001.123456" {
 		policySchemaString = `{
 			"created_at":"2022-11-02 10:30:20",
@@ -895,6 +1363,57 @@ func (s *SdlMock) Get(ns string, keys []string) (map[string]interface{}, error)
 	a1.Logger.Debug("Get Called and mp return %+v ", mp)
 	return mp, args.Error(1)
 }
+// memSdl keeps the data in memory and returns each key's own content
+type memSdl map[string]interface{}
+
+func (s memSdl) GetAll(ns string) ([]string, error) {
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (s memSdl) SetIfNotExists(ns string, key string, data interface{}) (bool, error) {
+	if _, ok := s[key]; ok {
+		return false, nil
+	}
+	s[key] = data
+	return true, nil
+}
+
+func (s memSdl) Get(ns string, keys []string) (map[string]interface{}, error) {
+	mp := map[string]interface{}{}
+	for _, key := range keys {
+		if value, ok := s[key]; ok {
+			mp[key] = value
+		}
+	}
+	return mp, nil
+}
+
+func (s memSdl) SetIf(ns string, key string, oldData, newData interface{}) (bool, error) {
+	if s[key] != oldData {
+		return false, nil
+	}
+	s[key] = newData
+	return true, nil
+}
+
+func (s memSdl) Set(ns string, pairs ...interface{}) error {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		s[pairs[i].(string)] = pairs[i+1]
+	}
+	return nil
+}
+
+func (s memSdl) Remove(ns string, keys []string) error {
+	for _, key := range keys {
+		delete(s, key)
+	}
+	return nil
+}
+
 func (s *SdlMock) SetIfNotExists(ns string, key string, data interface{}) (bool, error) {
 	args := s.MethodCalled("SetIfNotExists", ns, key, data)
 	return args.Bool(0), args.Error(1)
@@ -909,7 +1428,7 @@ func (s *SdlMock) SetIf(ns string, key string, oldData, newData interface{}) (bo
 	return args.Bool(0), args.Error(1)
 }
 
-func (rmr *RmrSenderMock) RmrSendToXapp(httpBodyString string, mtype int, subid int) bool {
+func (rmr *RmrSenderMock) RmrSendToXapp(httpBodyString string, mtype int, subid int, transactionId string) bool {
 	if httpBodyString == `{"blocking_rate":20,"enforce":true,"trigger_threshold":10,"window_length":20}` {
 		args := rmr.MethodCalled("RmrSendToXapp", httpBodyString, mtype, subid)
 		return args.Bool(0)
@@ -920,6 +1439,11 @@ func (rmr *RmrSenderMock) RmrSendToXapp(httpBodyString string, mtype int, subid
 	return true
 }
 
+func (rmr *RmrSenderMock) RmrSendToNode(httpBodyString string, mtype int, subid int, ranName string, transactionId string) bool {
+	args := rmr.MethodCalled("RmrSendToNode", httpBodyString, mtype, subid, ranName, transactionId)
+	return args.Bool(0)
+}
+
 func (s *SdlMock) Remove(ns string, keys []string) error {
 	args := s.MethodCalled("Remove", ns, keys)
 	return args.Error(0)
@@ -0,0 +1,178 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+package resthooks
+
+import (
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/models"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/policy"
+)
+
+// resyncInstance is a stored policy instance to send again to the xApps
+type resyncInstance struct {
+	policyTypeId     models.PolicyTypeID
+	policyInstanceID models.PolicyInstanceID
+	payload          string
+}
+
+// ResyncPolicies sends every stored instance of the policy type, or of all the
+// policy types when none is given, to the xApps again with a CREATE request.
+// The instances are sent in the background at the configured rate.
+func (rh *Resthook) ResyncPolicies(policyTypeId *models.PolicyTypeID) error {
+	if policyTypeId != nil {
+		if err := rh.typeValidity(*policyTypeId); err != nil {
+			return err
+		}
+	}
+	instances, err := rh.getResyncInstances(policyTypeId)
+	if err != nil {
+		return err
+	}
+	a1.Logger.Info("resyncing %d policy instances", len(instances))
+	go rh.resyncPolicyInstances(instances)
+	return nil
+}
+
+// getResyncInstances returns the stored instances the xApps should enforce.
+// Instances being deleted and instances whose validity window has not started
+// yet are left out.
+func (rh *Resthook) getResyncInstances(policyTypeId *models.PolicyTypeID) ([]resyncInstance, error) {
+	keys, err := rh.db.GetAll(a1MediatorNs)
+	if err != nil {
+		a1.Logger.Error("error in retrieving policy. err: %v", err)
+		return nil, err
+	}
+	prefix := a1InstancePrefix
+	if policyTypeId != nil {
+		prefix += strconv.FormatInt((int64(*policyTypeId)), 10) + "."
+	}
+	var instanceKeys []string
+	var getKeys []string
+	for _, key := range keys {
+		if strings.HasPrefix(key, prefix) {
+			suffix := strings.TrimPrefix(key, a1InstancePrefix)
+			instanceKeys = append(instanceKeys, key)
+			getKeys = append(getKeys, key, a1InstanceMetadataPrefix+suffix, a1SchedulePrefix+suffix)
+		}
+	}
+	if len(instanceKeys) == 0 {
+		return nil, nil
+	}
+	valmap, err := rh.db.Get(a1MediatorNs, getKeys)
+	if err != nil {
+		a1.Logger.Error("error in retrieving policy instances err: %v", err)
+		return nil, err
+	}
+
+	var instances []resyncInstance
+	for _, key := range instanceKeys {
+		suffix := strings.TrimPrefix(key, a1InstancePrefix)
+		ids := strings.SplitN(suffix, ".", 2)
+		if len(ids) != 2 {
+			continue
+		}
+		typeId, err := strconv.ParseInt(ids[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		payload, ok := resyncPayload(valmap, suffix)
+		if !ok {
+			continue
+		}
+		instances = append(instances, resyncInstance{
+			policyTypeId:     models.PolicyTypeID(typeId),
+			policyInstanceID: models.PolicyInstanceID(ids[1]),
+			payload:          payload,
+		})
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		if instances[i].policyTypeId != instances[j].policyTypeId {
+			return instances[i].policyTypeId < instances[j].policyTypeId
+		}
+		return instances[i].policyInstanceID < instances[j].policyInstanceID
+	})
+	return instances, nil
+}
+
+// resyncPayload returns the stored payload of the instance whose keys end with
+// suffix, unless the instance is gone, being deleted or waiting for its validity
+// window
+func resyncPayload(valmap map[string]interface{}, suffix string) (string, bool) {
+	payload, ok := valmap[a1InstancePrefix+suffix].(string)
+	if !ok {
+		return "", false
+	}
+	metadata, _ := valmap[a1InstanceMetadataPrefix+suffix].(string)
+	state := policy.InstanceMetadataState(metadata)
+	if state == policy.InstanceStateDeleting || state == policy.InstanceStateDeleted {
+		return "", false
+	}
+	if data, ok := valmap[a1SchedulePrefix+suffix].(string); ok {
+		var schedule policySchedule
+		if err := json.Unmarshal([]byte(data), &schedule); err == nil && schedule.Status == schedulePending {
+			return "", false
+		}
+	}
+	return payload, true
+}
+
+// currentResyncPayload reads the instance again, as it may have been updated or
+// deleted while the instances before it were resynced
+func (rh *Resthook) currentResyncPayload(instance resyncInstance) (string, bool, error) {
+	suffix := strconv.FormatInt((int64(instance.policyTypeId)), 10) + "." + string(instance.policyInstanceID)
+	valmap, err := rh.db.Get(a1MediatorNs, []string{a1InstancePrefix + suffix, a1InstanceMetadataPrefix + suffix, a1SchedulePrefix + suffix})
+	if err != nil {
+		a1.Logger.Error("error in retrieving policy instance err: %v", err)
+		return "", false, err
+	}
+	payload, ok := resyncPayload(valmap, suffix)
+	return payload, ok, nil
+}
+
+func (rh *Resthook) resyncPolicyInstances(instances []resyncInstance) {
+	resynced := 0
+	for i, instance := range instances {
+		if i > 0 && rh.resyncInterval > 0 {
+			time.Sleep(rh.resyncInterval)
+		}
+		payload, ok, err := rh.currentResyncPayload(instance)
+		if err != nil {
+			a1.Logger.Error("failed to resync policy instance %d.%s : %v", instance.policyTypeId, instance.policyInstanceID, err)
+			continue
+		}
+		if !ok {
+			a1.Logger.Debug("policy instance %d.%s is no longer to be resynced", instance.policyTypeId, instance.policyInstanceID)
+			continue
+		}
+		if err = rh.sendPolicyRequest(instance.policyTypeId, instance.policyInstanceID, payload, "CREATE", false); err != nil {
+			a1.Logger.Error("failed to resync policy instance %d.%s : %v", instance.policyTypeId, instance.policyInstanceID, err)
+			continue
+		}
+		resynced++
+	}
+	a1.Logger.Info("resynced %d of %d policy instances", resynced, len(instances))
+}
@@ -0,0 +1,199 @@
+/*
+==================================================================================
+  Copyright (c) 2021 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+package resthooks
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/models"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/restapi/operations/a1_mediator"
+)
+
+const (
+	schedulePending = a1_mediator.A1ControllerGetPolicyInstanceStatusOKBodyScheduleStatusPENDING
+	scheduleActive  = a1_mediator.A1ControllerGetPolicyInstanceStatusOKBodyScheduleStatusACTIVE
+)
+
+// policySchedule is the validity window of a policy instance as stored in SDL
+type policySchedule struct {
+	ValidFrom  string `json:"valid_from,omitempty"`
+	ValidUntil string `json:"valid_until,omitempty"`
+	Status     string `json:"status"`
+}
+
+func parseValidityWindow(validFrom string, validUntil string) (time.Time, time.Time, error) {
+	var from, until time.Time
+	var err error
+	if len(validFrom) > 0 {
+		if from, err = time.Parse(time.RFC3339, validFrom); err != nil {
+			a1.Logger.Error("invalid validFrom %s : %v", validFrom, err)
+			return from, until, invalidValidityWindowError
+		}
+	}
+	if len(validUntil) > 0 {
+		if until, err = time.Parse(time.RFC3339, validUntil); err != nil {
+			a1.Logger.Error("invalid validUntil %s : %v", validUntil, err)
+			return from, until, invalidValidityWindowError
+		}
+		if !until.After(time.Now()) || (!from.IsZero() && !until.After(from)) {
+			a1.Logger.Error("validUntil %s is not after validFrom %s or in the past", validUntil, validFrom)
+			return from, until, invalidValidityWindowError
+		}
+	}
+	return from, until, nil
+}
+
+func policyScheduleKey(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID) string {
+	return a1SchedulePrefix + strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID)
+}
+
+func (rh *Resthook) storePolicySchedule(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID, schedule policySchedule) error {
+	data, err := json.Marshal(schedule)
+	if err != nil {
+		a1.Logger.Error("marshal error : %v", err)
+		return err
+	}
+	scheduleKey := policyScheduleKey(policyTypeId, policyInstanceID)
+	a1.Logger.Debug("policy schedule %s : %s", scheduleKey, string(data))
+	if err = rh.db.Set(a1MediatorNs, scheduleKey, string(data)); err != nil {
+		a1.Logger.Error("error :%+v", err)
+		return err
+	}
+	return nil
+}
+
+func (rh *Resthook) getPolicySchedule(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID) (*policySchedule, error) {
+	var keys [1]string
+	scheduleKey := policyScheduleKey(policyTypeId, policyInstanceID)
+	keys[0] = scheduleKey
+	valmap, err := rh.db.Get(a1MediatorNs, keys[:])
+	if err != nil {
+		a1.Logger.Error("error in retrieving policy schedule err: %v", err)
+		return nil, err
+	}
+	data, ok := valmap[scheduleKey].(string)
+	if !ok {
+		return nil, nil
+	}
+	var schedule policySchedule
+	if err = json.Unmarshal([]byte(data), &schedule); err != nil {
+		a1.Logger.Error("unmarshal error : %v", err)
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (rh *Resthook) deletePolicySchedule(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID) error {
+	// a window that already started and has no end has no timer left, its
+	// record is removed all the same
+	scheduleKey := policyScheduleKey(policyTypeId, policyInstanceID)
+	rh.scheduleTimers.cancel(scheduleKey)
+	var keys [1]string
+	keys[0] = scheduleKey
+	if err := rh.db.Remove(a1MediatorNs, keys[:]); err != nil {
+		a1.Logger.Error("error in deleting policy schedule err: %v", err)
+		return err
+	}
+	return nil
+}
+
+// schedulePolicyInstance arms the activation and expiry timers of an instance.
+// An instance whose activation time has already passed is activated at once.
+func (rh *Resthook) schedulePolicyInstance(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID, schedule policySchedule) {
+	scheduleKey := policyScheduleKey(policyTypeId, policyInstanceID)
+	if schedule.Status == schedulePending {
+		from, _ := time.Parse(time.RFC3339, schedule.ValidFrom)
+		rh.scheduleTimers.add(scheduleKey, from, func() {
+			rh.activatePolicyInstance(policyTypeId, policyInstanceID)
+		})
+	}
+	if len(schedule.ValidUntil) > 0 {
+		until, _ := time.Parse(time.RFC3339, schedule.ValidUntil)
+		rh.scheduleTimers.add(scheduleKey, until, func() {
+			rh.expirePolicyInstance(policyTypeId, policyInstanceID)
+		})
+	}
+}
+
+func (rh *Resthook) activatePolicyInstance(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID) {
+	a1.Logger.Info("activating policy instance %d.%s", policyTypeId, policyInstanceID)
+	schedule, err := rh.getPolicySchedule(policyTypeId, policyInstanceID)
+	if err != nil || schedule == nil {
+		a1.Logger.Error("policy schedule of %d.%s not found : %v", policyTypeId, policyInstanceID, err)
+		return
+	}
+	instance, err := rh.GetPolicyInstance(policyTypeId, policyInstanceID)
+	if err != nil {
+		a1.Logger.Error("policy instance error : %v", err)
+		return
+	}
+	schedule.Status = scheduleActive
+	if err = rh.storePolicySchedule(policyTypeId, policyInstanceID, *schedule); err != nil {
+		return
+	}
+	httpBodyMarshal, err := json.Marshal(instance)
+	if err != nil {
+		a1.Logger.Error("marshal error : %v", err)
+		return
+	}
+	rh.sendPolicyRequest(policyTypeId, policyInstanceID, string(httpBodyMarshal), "CREATE", false)
+}
+
+func (rh *Resthook) expirePolicyInstance(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID) {
+	a1.Logger.Info("policy instance %d.%s expired", policyTypeId, policyInstanceID)
+	if err := rh.DeletePolicyInstance(policyTypeId, policyInstanceID, false); err != nil {
+		a1.Logger.Error("failed to delete expired policy instance %d.%s : %v", policyTypeId, policyInstanceID, err)
+	}
+}
+
+// restorePolicySchedules re-arms the timers of the scheduled policy instances
+// stored in SDL, so validity windows survive a restart of the mediator
+func (rh *Resthook) restorePolicySchedules() {
+	keys, err := rh.db.GetAll(a1MediatorNs)
+	if err != nil {
+		a1.Logger.Error("error in retrieving policy schedules err: %v", err)
+		return
+	}
+	for _, key := range keys {
+		if !strings.HasPrefix(key, a1SchedulePrefix) {
+			continue
+		}
+		ids := strings.SplitN(strings.TrimPrefix(key, a1SchedulePrefix), ".", 2)
+		if len(ids) != 2 {
+			continue
+		}
+		policyTypeId, err := strconv.ParseInt(ids[0], 10, 64)
+		if err != nil {
+			continue
+		}
+		policyInstanceID := models.PolicyInstanceID(ids[1])
+		schedule, err := rh.getPolicySchedule(models.PolicyTypeID(policyTypeId), policyInstanceID)
+		if err != nil || schedule == nil {
+			continue
+		}
+		a1.Logger.Debug("restoring policy schedule %s : %+v", key, schedule)
+		rh.schedulePolicyInstance(models.PolicyTypeID(policyTypeId), policyInstanceID, *schedule)
+	}
+}
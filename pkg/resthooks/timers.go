@@ -0,0 +1,109 @@
+/*
+==================================================================================
+  Copyright (c) 2021 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+package resthooks
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// timerSet keeps timers by key, several timers may be armed for one key. It
+// holds the validity window, acknowledgement and delete deadlines of the policy
+// instances and the retries of the RMR outbox.
+type timerSet struct {
+	mutex  sync.Mutex
+	timers map[string][]*time.Timer
+}
+
+func newTimerSet() *timerSet {
+	return &timerSet{
+		timers: make(map[string][]*time.Timer),
+	}
+}
+
+// add arms a timer running fn at the given time under the key, the timer is
+// dropped from the set once it fires
+func (ts *timerSet) add(key string, at time.Time, fn func()) {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+	var timer *time.Timer
+	timer = time.AfterFunc(time.Until(at), func() {
+		// the timer is read under the mutex, add assigns it before unlocking
+		ts.mutex.Lock()
+		ts.remove(key, timer)
+		ts.mutex.Unlock()
+		fn()
+	})
+	ts.timers[key] = append(ts.timers[key], timer)
+}
+
+// remove drops the timer from the key, timers armed later under the same key
+// are kept. The caller holds the mutex.
+func (ts *timerSet) remove(key string, timer *time.Timer) {
+	timers := ts.timers[key]
+	for i, t := range timers {
+		if t != timer {
+			continue
+		}
+		timers = append(timers[:i:i], timers[i+1:]...)
+		break
+	}
+	if len(timers) == 0 {
+		delete(ts.timers, key)
+		return
+	}
+	ts.timers[key] = timers
+}
+
+// cancel stops all timers of the key and reports whether any timer was armed
+// for it
+func (ts *timerSet) cancel(key string) bool {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+	timers, ok := ts.timers[key]
+	for _, timer := range timers {
+		timer.Stop()
+	}
+	delete(ts.timers, key)
+	return ok
+}
+
+// cancelPrefix stops the timers of every key starting with the prefix and
+// returns those keys
+func (ts *timerSet) cancelPrefix(prefix string) []string {
+	ts.mutex.Lock()
+	defer ts.mutex.Unlock()
+	var keys []string
+	for key, timers := range ts.timers {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		for _, timer := range timers {
+			timer.Stop()
+		}
+		delete(ts.timers, key)
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
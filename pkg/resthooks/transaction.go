@@ -0,0 +1,87 @@
+/*
+==================================================================================
+  Copyright (c) 2021 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+package resthooks
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/models"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/policy"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/rmr"
+)
+
+// newPolicyTransaction records a new outstanding request for the instance. The
+// sequence number is incremented for every request, so that responses to older
+// requests can be told apart and xApps can order the requests. The revision is
+// only incremented when newRevision tells that the body of the instance was
+// created or updated; a resync or a scheduled activation sends the revision the
+// body already has. The request is sent to the RAN nodes kept in the instance
+// metadata.
+func (rh *Resthook) newPolicyTransaction(policyTypeId models.PolicyTypeID, policyInstanceID models.PolicyInstanceID, operation string, newRevision bool) (*policy.PolicyTransaction, error) {
+	suffix := strconv.FormatInt((int64(policyTypeId)), 10) + "." + string(policyInstanceID)
+	transactionKey := a1TransactionPrefix + suffix
+	valmap, err := rh.db.Get(a1MediatorNs, []string{transactionKey, a1InstanceMetadataPrefix + suffix})
+	if err != nil {
+		a1.Logger.Error("error in retrieving policy transaction err: %v", err)
+		return nil, err
+	}
+	now := time.Now().Format("2006-01-02 15:04:05")
+	transaction := &policy.PolicyTransaction{
+		TransactionID: rmr.NewTransactionID(),
+		Operation:     operation,
+		UpdatedAt:     now,
+		SentAt:        now,
+	}
+	if previous, err := policy.ParsePolicyTransaction(valmap[transactionKey]); err == nil && previous != nil {
+		transaction.Revision = previous.Revision
+		transaction.Sequence = previous.Sequence
+		transaction.CreatedAt = previous.CreatedAt
+		if operation == "DELETE" {
+			transaction.UpdatedAt = previous.UpdatedAt
+		}
+	}
+	if newRevision || (transaction.Revision == 0 && operation != "DELETE") {
+		transaction.Revision++
+	}
+	transaction.Sequence++
+	if metadata, ok := valmap[a1InstanceMetadataPrefix+suffix].(string); ok {
+		if len(metadataCreatedAt(metadata)) > 0 {
+			transaction.CreatedAt = metadataCreatedAt(metadata)
+		}
+		transaction.RanNames = policy.InstanceMetadataRanNames(metadata)
+	}
+	if len(transaction.CreatedAt) == 0 {
+		transaction.CreatedAt = transaction.UpdatedAt
+	}
+	data, err := json.Marshal(transaction)
+	if err != nil {
+		a1.Logger.Error("marshal error : %v", err)
+		return nil, err
+	}
+	if err = rh.db.Set(a1MediatorNs, transactionKey, string(data)); err != nil {
+		a1.Logger.Error("error in storing policy transaction err: %v", err)
+		return nil, err
+	}
+	return transaction, nil
+}
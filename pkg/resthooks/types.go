@@ -21,6 +21,8 @@
 package resthooks
 
 import (
+	"time"
+
 	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/rmr"
 	"gerrit.o-ran-sc.org/r/ric-plt/xapp-frame/pkg/xapp"
 )
@@ -28,6 +30,15 @@
 type Resthook struct {
 	db             iSdl
 	iRmrSenderInst rmr.IRmrSender
+	scheduleTimers *timerSet
+	ackTimers      *timerSet
+	ackTimeout     time.Duration
+	deleteTimers   *timerSet
+	deleteTimeout  time.Duration
+	outbox         *timerSet
+	rmrRetry       rmrRetryPolicy
+	resyncInterval time.Duration
+	messageVersion int
 }
 type iSdl interface {
 	GetAll(string) ([]string, error)
@@ -0,0 +1,145 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package rmr
+
+import (
+	"encoding/json"
+	"strconv"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+)
+
+// eiJobReply answers the requests about one EI job, with the definition and
+// the status ECS knows when the job is queried
+type eiJobReply struct {
+	JobId  int64           `json:"job-id"`
+	Job    json.RawMessage `json:"job,omitempty"`
+	Status json.RawMessage `json:"status,omitempty"`
+}
+
+// eiJobsReply lists the ids of the EI jobs of an owner
+type eiJobsReply struct {
+	Owner  string          `json:"owner"`
+	JobIds json.RawMessage `json:"job-ids"`
+}
+
+func eiJobIdOf(result map[string]interface{}) (int64, string) {
+	jobId := int64(result["job-id"].(float64))
+	return jobId, strconv.FormatInt(jobId, 10)
+}
+
+// sendEiReply sends the answer to an A1-EI request, or an A1_EI_ERROR when
+// it cannot be encoded
+func (rmr *RmrSender) sendEiReply(request string, jobId *int64, messageType string, reply interface{}) {
+	data, err := json.Marshal(reply)
+	if err != nil {
+		a1.Logger.Error("marshal error : %v", err)
+		rmr.sendEiError(request, jobId, err)
+		return
+	}
+	if !rmr.RmrSendToXapp(string(data), MessageTypeID(messageType), DefaultSubId, "") {
+		a1.Logger.Error("rmrSendToXapp : message not sent")
+	}
+}
+
+// handleEiQueryJob sends the definition and the status of an EI job
+func (rmr *RmrSender) handleEiQueryJob(job *rmrJob) error {
+	jobId, jobIdStr := eiJobIdOf(job.result)
+	if rmr.ecs == nil {
+		rmr.sendEiError(job.name, &jobId, ecsNotConfiguredError)
+		return nil
+	}
+	eiJob, err := rmr.ecs.GetEiJob(jobIdStr)
+	if err != nil {
+		a1.Logger.Warning("failed to get EIJOB %s : %v", jobIdStr, err)
+		rmr.sendEiError(job.name, &jobId, err)
+		return nil
+	}
+	status, err := rmr.ecs.GetEiJobStatus(jobIdStr)
+	if err != nil {
+		a1.Logger.Warning("failed to get the status of EIJOB %s : %v", jobIdStr, err)
+		rmr.sendEiError(job.name, &jobId, err)
+		return nil
+	}
+	rmr.sendEiReply(job.name, &jobId, A1EiQueryJobResp, eiJobReply{JobId: jobId, Job: eiJob, Status: status})
+	return nil
+}
+
+// handleEiUpdateJob replaces the definition of an existing EI job. Unlike
+// A1_EI_CREATE_JOB it does not create unknown jobs, ECS answering 404 for them.
+func (rmr *RmrSender) handleEiUpdateJob(job *rmrJob) error {
+	jobId, jobIdStr := eiJobIdOf(job.result)
+	jsonReq, err := json.Marshal(job.result)
+	if err != nil {
+		a1.Logger.Error("marshal error : %v", err)
+		return err
+	}
+	if rmr.ecs == nil {
+		rmr.sendEiError(job.name, &jobId, ecsNotConfiguredError)
+		return nil
+	}
+	if _, err = rmr.ecs.GetEiJob(jobIdStr); err == nil {
+		_, err = rmr.ecs.PutEiJob(jobIdStr, jsonReq)
+	}
+	if err != nil {
+		a1.Logger.Warning("failed to update EIJOB %s : %v", jobIdStr, err)
+		rmr.sendEiError(job.name, &jobId, err)
+		return nil
+	}
+	rmr.sendEiReply(job.name, &jobId, A1EiUpdateJobResp, eiJobReply{JobId: jobId})
+	return nil
+}
+
+// handleEiDeleteJob deletes an EI job in ECS
+func (rmr *RmrSender) handleEiDeleteJob(job *rmrJob) error {
+	jobId, jobIdStr := eiJobIdOf(job.result)
+	if rmr.ecs == nil {
+		rmr.sendEiError(job.name, &jobId, ecsNotConfiguredError)
+		return nil
+	}
+	if err := rmr.ecs.DeleteEiJob(jobIdStr); err != nil {
+		a1.Logger.Warning("failed to delete EIJOB %s : %v", jobIdStr, err)
+		rmr.sendEiError(job.name, &jobId, err)
+		return nil
+	}
+	rmr.sendEiReply(job.name, &jobId, A1EiDeleteJobResp, eiJobReply{JobId: jobId})
+	return nil
+}
+
+// handleEiQueryJobs sends the ids of the EI jobs of an owner, optionally of
+// one EI type only
+func (rmr *RmrSender) handleEiQueryJobs(job *rmrJob) error {
+	owner := job.result["owner"].(string)
+	eiTypeId, _ := job.result["ei_type_id"].(string)
+	if rmr.ecs == nil {
+		rmr.sendEiError(job.name, nil, ecsNotConfiguredError)
+		return nil
+	}
+	jobIds, err := rmr.ecs.GetEiJobIds(owner, eiTypeId)
+	if err != nil {
+		a1.Logger.Warning("failed to get the EIJOBs of %s : %v", owner, err)
+		rmr.sendEiError(job.name, nil, err)
+		return nil
+	}
+	rmr.sendEiReply(job.name, nil, A1EiQueryJobsResp, eiJobsReply{Owner: owner, JobIds: jobIds})
+	return nil
+}
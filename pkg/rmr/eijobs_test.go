@@ -0,0 +1,133 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package rmr
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/config"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/ecs"
+	"gerrit.o-ran-sc.org/r/ric-plt/xapp-frame/pkg/xapp"
+	"github.com/stretchr/testify/assert"
+)
+
+// ecsStub serves EI job 12 owned by xapp1, the other jobs are unknown
+func ecsStub(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /A1-EI/v1/eijobs/12":
+			w.Write([]byte(`{"eiTypeId":"type1","jobOwner":"xapp1"}`))
+		case "GET /A1-EI/v1/eijobs/12/status":
+			w.Write([]byte(`{"eiJobStatus":"ENABLED"}`))
+		case "PUT /A1-EI/v1/eijobs/12":
+			w.WriteHeader(http.StatusOK)
+		case "DELETE /A1-EI/v1/eijobs/12":
+			w.WriteHeader(http.StatusNoContent)
+		case "GET /A1-EI/v1/eijobs":
+			assert.Equal(t, "xapp1", r.URL.Query().Get("owner"))
+			w.Write([]byte(`["12"]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func eiJobSender(t *testing.T, url string, messageType string) (*RmrSender, *[]*xapp.RMRParams) {
+	client, err := ecs.NewClient(&config.Configuration{EcsURL: url, EcsAPIVersion: "v1", EcsConnectTimeout: 1, EcsRequestTimeout: 1, EcsRetryMaxAttempts: 1})
+	assert.Nil(t, err)
+	transport := NewFakeTransport()
+	var received []*xapp.RMRParams
+	transport.Handle(MessageTypeID(messageType), func(transport *FakeTransport, params *xapp.RMRParams) {
+		received = append(received, params)
+	})
+	return &RmrSender{transport: transport, ecs: client}, &received
+}
+
+func eiRequest(t *testing.T, name string, payload string) *rmrJob {
+	result, err := validateMessage(name, []byte(payload))
+	assert.Nil(t, err)
+	return &rmrJob{name: name, msg: &xapp.RMRParams{Payload: []byte(payload)}, result: result}
+}
+
+func TestHandleEiQueryJob(t *testing.T) {
+	server := ecsStub(t)
+	defer server.Close()
+	sender, received := eiJobSender(t, server.URL, A1EiQueryJobResp)
+
+	assert.Nil(t, sender.handleEiQueryJob(eiRequest(t, A1EiQueryJob, `{"job-id":12}`)))
+	assert.Equal(t, 1, len(*received))
+	var reply eiJobReply
+	assert.Nil(t, json.Unmarshal((*received)[0].Payload, &reply))
+	assert.Equal(t, int64(12), reply.JobId)
+	assert.JSONEq(t, `{"eiTypeId":"type1","jobOwner":"xapp1"}`, string(reply.Job))
+	assert.JSONEq(t, `{"eiJobStatus":"ENABLED"}`, string(reply.Status))
+}
+
+func TestHandleEiUpdateJob(t *testing.T) {
+	server := ecsStub(t)
+	defer server.Close()
+	sender, received := eiJobSender(t, server.URL, A1EiUpdateJobResp)
+
+	assert.Nil(t, sender.handleEiUpdateJob(eiRequest(t, A1EiUpdateJob, `{"job-id":12,"eiTypeId":"type1"}`)))
+	assert.Equal(t, 1, len(*received))
+	assert.JSONEq(t, `{"job-id":12}`, string((*received)[0].Payload))
+}
+
+func TestHandleEiUpdateUnknownJob(t *testing.T) {
+	server := ecsStub(t)
+	defer server.Close()
+	sender, received := eiJobSender(t, server.URL, A1EiError)
+
+	assert.Nil(t, sender.handleEiUpdateJob(eiRequest(t, A1EiUpdateJob, `{"job-id":13}`)))
+	assert.Equal(t, 1, len(*received))
+	var reply eiError
+	assert.Nil(t, json.Unmarshal((*received)[0].Payload, &reply))
+	assert.Equal(t, A1EiUpdateJob, reply.Request)
+	assert.Equal(t, int64(13), *reply.JobId)
+	assert.Equal(t, http.StatusNotFound, reply.StatusCode)
+}
+
+func TestHandleEiDeleteJob(t *testing.T) {
+	server := ecsStub(t)
+	defer server.Close()
+	sender, received := eiJobSender(t, server.URL, A1EiDeleteJobResp)
+
+	assert.Nil(t, sender.handleEiDeleteJob(eiRequest(t, A1EiDeleteJob, `{"job-id":12}`)))
+	assert.Equal(t, 1, len(*received))
+	assert.JSONEq(t, `{"job-id":12}`, string((*received)[0].Payload))
+}
+
+func TestHandleEiQueryJobs(t *testing.T) {
+	server := ecsStub(t)
+	defer server.Close()
+	sender, received := eiJobSender(t, server.URL, A1EiQueryJobsResp)
+
+	assert.Nil(t, sender.handleEiQueryJobs(eiRequest(t, A1EiQueryJobs, `{"owner":"xapp1"}`)))
+	assert.Equal(t, 1, len(*received))
+	assert.JSONEq(t, `{"owner":"xapp1","job-ids":["12"]}`, string((*received)[0].Payload))
+
+	_, err := validateMessage(A1EiQueryJobs, []byte(`{"ei_type_id":"type1"}`))
+	assert.NotNil(t, err)
+}
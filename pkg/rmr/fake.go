@@ -0,0 +1,108 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+package rmr
+
+import (
+	"sync"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/xapp-frame/pkg/xapp"
+)
+
+// FakeHandler is a simulated xApp receiving the messages A1 sends with one
+// message type, it may answer through the transport
+type FakeHandler func(transport *FakeTransport, params *xapp.RMRParams)
+
+// FakeTransport delivers the RMR messages in process, without the RMR library
+// and its route table, between A1 and simulated xApps registered as Go
+// handlers. Messages are delivered synchronously, in the order they are sent.
+type FakeTransport struct {
+	mutex    sync.RWMutex
+	handlers map[int][]FakeHandler
+	consumer xapp.MessageConsumer
+}
+
+var defaultFakeTransport = NewFakeTransport()
+
+func NewFakeTransport() *FakeTransport {
+	return &FakeTransport{
+		handlers: map[int][]FakeHandler{},
+	}
+}
+
+// DefaultFakeTransport returns the transport used by A1 when RMR_TRANSPORT is
+// fake, simulated xApps register their handlers on it
+func DefaultFakeTransport() *FakeTransport {
+	return defaultFakeTransport
+}
+
+// Handle registers a simulated xApp for the messages of the message type
+func (t *FakeTransport) Handle(messageType int, handler FakeHandler) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.handlers[messageType] = append(t.handlers[messageType], handler)
+}
+
+func (t *FakeTransport) Start(consumer xapp.MessageConsumer) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.consumer = consumer
+}
+
+// SendMsg delivers a message of A1 to the simulated xApps handling its type,
+// it fails like a missing route when there is none
+func (t *FakeTransport) SendMsg(params *xapp.RMRParams) bool {
+	t.mutex.RLock()
+	handlers := t.handlers[params.Mtype]
+	t.mutex.RUnlock()
+	if len(handlers) == 0 {
+		return false
+	}
+	for _, handler := range handlers {
+		handler(t, copyRMRParams(params))
+	}
+	return true
+}
+
+// Send delivers a message of a simulated xApp to A1
+func (t *FakeTransport) Send(params *xapp.RMRParams) bool {
+	t.mutex.RLock()
+	consumer := t.consumer
+	t.mutex.RUnlock()
+	if consumer == nil {
+		return false
+	}
+	consumer.Consume(copyRMRParams(params))
+	return true
+}
+
+func (t *FakeTransport) Free(params *xapp.RMRParams) {
+}
+
+func copyRMRParams(params *xapp.RMRParams) *xapp.RMRParams {
+	msg := *params
+	msg.Payload = append([]byte(nil), params.Payload...)
+	msg.PayloadLen = len(msg.Payload)
+	if params.Meid == nil {
+		msg.Meid = &xapp.RMRMeid{}
+	}
+	msg.Mbuf = nil
+	return &msg
+}
@@ -0,0 +1,76 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package rmr
+
+import (
+	"testing"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/xapp-frame/pkg/xapp"
+	"github.com/stretchr/testify/assert"
+)
+
+type consumerMock struct {
+	received []*xapp.RMRParams
+}
+
+func (c *consumerMock) Consume(params *xapp.RMRParams) error {
+	c.received = append(c.received, params)
+	return nil
+}
+
+func TestFakeTransportSend(t *testing.T) {
+	transport := NewFakeTransport()
+	sender := &RmrSender{transport: transport}
+	var received []*xapp.RMRParams
+	transport.Handle(MessageTypeID(A1PolicyRequest), func(transport *FakeTransport, params *xapp.RMRParams) {
+		received = append(received, params)
+	})
+
+	body := `{"operation":"CREATE","policy_type_id":"20001","policy_instance_id":"123456","payload":"","transaction_id":"0123456789abcdef"}`
+	assert.True(t, sender.RmrSendToXapp(body, MessageTypeID(A1PolicyRequest), 20001, "0123456789abcdef"))
+	assert.False(t, sender.RmrSendToXapp(`{}`, MessageTypeID(A1EiQueryAllResp), DefaultSubId, ""))
+
+	assert.Equal(t, 1, len(received))
+	assert.Equal(t, body, string(received[0].Payload))
+	assert.Equal(t, "0123456789abcdef", received[0].Xid)
+	assert.Equal(t, 20001, received[0].SubId)
+	assert.Equal(t, "", received[0].Meid.RanName)
+
+	assert.True(t, sender.RmrSendToNode(body, MessageTypeID(A1PolicyRequest), 20001, "gnb_001", "0123456789abcdef"))
+	assert.Equal(t, 2, len(received))
+	assert.Equal(t, "gnb_001", received[1].Meid.RanName)
+}
+
+func TestFakeTransportReceive(t *testing.T) {
+	transport := NewFakeTransport()
+	payload := []byte(`{"policy_type_id":20001}`)
+	assert.False(t, transport.Send(&xapp.RMRParams{Mtype: MessageTypeID(A1PolicyQuery), Payload: payload}))
+
+	consumer := &consumerMock{}
+	transport.Start(consumer)
+	assert.True(t, transport.Send(&xapp.RMRParams{Mtype: MessageTypeID(A1PolicyQuery), Payload: payload}))
+
+	assert.Equal(t, 1, len(consumer.received))
+	assert.Equal(t, payload, consumer.received[0].Payload)
+	assert.Equal(t, len(payload), consumer.received[0].PayloadLen)
+	assert.NotNil(t, consumer.received[0].Meid)
+}
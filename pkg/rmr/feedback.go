@@ -0,0 +1,65 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package rmr
+
+import (
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/policy"
+	"gerrit.o-ran-sc.org/r/ric-plt/xapp-frame/pkg/xapp"
+)
+
+func parsePolicyFeedback(msg *xapp.RMRParams, result map[string]interface{}) policy.PolicyFeedback {
+	feedback := policy.PolicyFeedback{Kpis: map[string]float64{}}
+	feedback.HandlerID, _ = result["handler_id"].(string)
+	if kpis, ok := result["kpis"].(map[string]interface{}); ok {
+		for name, value := range kpis {
+			if number, ok := value.(float64); ok {
+				feedback.Kpis[name] = number
+			}
+		}
+	}
+	feedback.MeasuredAt, _ = result["measured_at"].(string)
+	feedback.RanName, _ = result["ran_name"].(string)
+	if len(feedback.RanName) == 0 && msg.Meid != nil {
+		feedback.RanName = msg.Meid.RanName
+	}
+	return feedback
+}
+
+// handlePolicyFeedback stores the KPIs a handler measured for a policy instance.
+// The feedback about an unknown instance is discarded.
+func (rmr *RmrSender) handlePolicyFeedback(job *rmrJob) error {
+	policyTypeId := int(job.result["policy_type_id"].(float64))
+	policyInstanceId := job.result["policy_instance_id"].(string)
+	feedback := parsePolicyFeedback(job.msg, job.result)
+	a1.Logger.Debug("Recived policy feedback from %s for %d and %s", feedback.HandlerID, policyTypeId, policyInstanceId)
+	err := rmr.policyManager.RecordPolicyFeedback(policyTypeId, policyInstanceId, feedback, rmr.feedbackSamples)
+	if policy.IsPolicyInstanceNotFound(err) {
+		a1.Logger.Warning("ignoring feedback of %s for unknown policy instance %d.%s", feedback.HandlerID, policyTypeId, policyInstanceId)
+		return nil
+	}
+	if err != nil {
+		a1.Logger.Error("failed to record policy feedback : %v", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package rmr
+
+import (
+	"testing"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/xapp-frame/pkg/xapp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePolicyFeedback(t *testing.T) {
+	msg := &xapp.RMRParams{Meid: &xapp.RMRMeid{RanName: "gnb_002"}}
+	result, err := validateMessage(A1PolicyFeedback, []byte(`{"policy_type_id":20001,"policy_instance_id":"123456","handler_id":"qp","kpis":{"throughput":12.5,"latency":2},"measured_at":"2022-11-02 10:30:59"}`))
+	assert.Nil(t, err)
+	feedback := parsePolicyFeedback(msg, result)
+	assert.Equal(t, "qp", feedback.HandlerID)
+	assert.Equal(t, map[string]float64{"throughput": 12.5, "latency": 2}, feedback.Kpis)
+	assert.Equal(t, "2022-11-02 10:30:59", feedback.MeasuredAt)
+	assert.Equal(t, "gnb_002", feedback.RanName)
+
+	result, err = validateMessage(A1PolicyFeedback, []byte(`{"policy_type_id":20001,"policy_instance_id":"123456","handler_id":"qp","kpis":{"throughput":1},"ran_name":"gnb_001"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, "gnb_001", parsePolicyFeedback(msg, result).RanName)
+}
+
+func TestValidatePolicyFeedbackFail(t *testing.T) {
+	for _, payload := range []string{
+		`{"policy_type_id":20001,"policy_instance_id":"123456","handler_id":"qp"}`,
+		`{"policy_type_id":20001,"policy_instance_id":"123456","handler_id":"qp","kpis":{}}`,
+		`{"policy_type_id":20001,"policy_instance_id":"123456","handler_id":"qp","kpis":{"throughput":"high"}}`,
+		`{"policy_type_id":20001,"handler_id":"qp","kpis":{"throughput":1}}`,
+	} {
+		_, err := validateMessage(A1PolicyFeedback, []byte(payload))
+		assert.NotNil(t, err, payload)
+	}
+}
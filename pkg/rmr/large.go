@@ -0,0 +1,186 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package rmr
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/metrics"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/policy"
+	"gerrit.o-ran-sc.org/r/ric-plt/xapp-frame/pkg/xapp"
+)
+
+// strategies selected by LARGE_MESSAGE_STRATEGY for the messages over MAX_SIZE
+const (
+	LargeMessageNone      = "none"
+	LargeMessageFragment  = "fragment"
+	LargeMessageReference = "reference"
+)
+
+// fragmentOverhead is the room left in every fragment for its JSON envelope
+const fragmentOverhead = 256
+
+// MessageFragment is one part of a message too large to be sent at once. The
+// fragments keep the message type and are sent in order, the xApps concatenate
+// their data once all Count of them are received.
+type MessageFragment struct {
+	Fragment FragmentInfo `json:"fragment"`
+	// Data is encoded in base64 in the JSON message
+	Data []byte `json:"data"`
+}
+
+// FragmentInfo describes how a fragment fits in the original message
+type FragmentInfo struct {
+	ID    string `json:"id"`
+	Index int    `json:"index"`
+	Count int    `json:"count"`
+	Size  int    `json:"size"`
+}
+
+// MessageReference replaces a message too large to be sent, the xApps read the
+// original payload from SDL
+type MessageReference struct {
+	PayloadRef *policy.MessagePayloadRef `json:"payload_ref"`
+}
+
+// fragmentPayload splits the payload in messages of at most maxSize bytes
+func fragmentPayload(id string, payload []byte, maxSize int) ([][]byte, error) {
+	chunkSize := (maxSize - fragmentOverhead) / 4 * 3
+	if chunkSize <= 0 {
+		return nil, fmt.Errorf("maximum message size %d too small to fragment messages", maxSize)
+	}
+	count := (len(payload) + chunkSize - 1) / chunkSize
+	fragments := make([][]byte, 0, count)
+	for index := 0; index < count; index++ {
+		end := (index + 1) * chunkSize
+		if end > len(payload) {
+			end = len(payload)
+		}
+		fragment, err := json.Marshal(MessageFragment{
+			Fragment: FragmentInfo{ID: id, Index: index, Count: count, Size: len(payload)},
+			Data:     payload[index*chunkSize : end],
+		})
+		if err != nil {
+			return nil, err
+		}
+		fragments = append(fragments, fragment)
+	}
+	return fragments, nil
+}
+
+// sendLargeMessage sends a message over the maximum size as configured by
+// LARGE_MESSAGE_STRATEGY
+func (rmr *RmrSender) sendLargeMessage(params *xapp.RMRParams) bool {
+	switch rmr.largeMessage {
+	case LargeMessageFragment:
+		return rmr.sendFragments(params)
+	case LargeMessageReference:
+		return rmr.sendReference(params)
+	}
+	a1.Logger.Error("message of type %d is %d bytes, over the maximum of %d, not sent", params.Mtype, params.PayloadLen, rmr.maxSize)
+	metrics.IncCounter(metrics.OversizedRmrMessage)
+	return false
+}
+
+func (rmr *RmrSender) sendFragments(params *xapp.RMRParams) bool {
+	id := params.Xid
+	if len(id) == 0 {
+		id = NewTransactionID()
+	}
+	fragments, err := fragmentPayload(id, params.Payload, rmr.maxSize)
+	if err != nil {
+		a1.Logger.Error("failed to fragment message of type %d : %v", params.Mtype, err)
+		metrics.IncCounter(metrics.OversizedRmrMessage)
+		return false
+	}
+	a1.Logger.Debug("sending message of type %d and %d bytes in %d fragments", params.Mtype, params.PayloadLen, len(fragments))
+	for index, fragment := range fragments {
+		fragmentParams := *params
+		fragmentParams.Xid = id
+		fragmentParams.Payload = fragment
+		fragmentParams.PayloadLen = len(fragment)
+		if !rmr.transport.SendMsg(&fragmentParams) {
+			a1.Logger.Error("fragment %d of %d of message %s not sent", index+1, len(fragments), id)
+			return false
+		}
+	}
+	metrics.IncCounter(metrics.FragmentedRmrMessage)
+	return true
+}
+
+// sendReference stores the payload in SDL and sends its location instead. The
+// payload is removed once the xApps had LARGE_MESSAGE_REFERENCE_TTL to read it.
+func (rmr *RmrSender) sendReference(params *xapp.RMRParams) bool {
+	if rmr.policyManager == nil {
+		a1.Logger.Error("no SDL to store the payload of message of type %d", params.Mtype)
+		return false
+	}
+	ref, err := rmr.policyManager.StoreMessagePayload(NewTransactionID(), params.Payload, rmr.referenceTTL)
+	if err != nil {
+		return false
+	}
+	payload, err := json.Marshal(MessageReference{PayloadRef: ref})
+	if err != nil {
+		a1.Logger.Error("marshal error : %v", err)
+		return false
+	}
+	referenceParams := *params
+	referenceParams.Payload = payload
+	referenceParams.PayloadLen = len(payload)
+	sent := rmr.transport.SendMsg(&referenceParams)
+	if !sent {
+		rmr.policyManager.RemoveMessagePayload(ref)
+		return false
+	}
+	a1.Logger.Debug("payload of message of type %d and %d bytes sent as %s", params.Mtype, params.PayloadLen, ref.Key)
+	metrics.IncCounter(metrics.ReferencedRmrMessage)
+	rmr.expireReference(ref)
+	return true
+}
+
+// expireReference removes the payload of the reference once it expires
+func (rmr *RmrSender) expireReference(ref *policy.MessagePayloadRef) {
+	expiry, err := time.Parse(time.RFC3339, ref.ExpiresAt)
+	if err != nil {
+		a1.Logger.Error("invalid expiry of message payload %s : %v", ref.Key, err)
+		rmr.policyManager.RemoveMessagePayload(ref)
+		return
+	}
+	time.AfterFunc(time.Until(expiry), func() {
+		rmr.policyManager.RemoveMessagePayload(ref)
+	})
+}
+
+// restoreReferences removes the payloads that expired while A1 was down and
+// arms the expiry of the others
+func (rmr *RmrSender) restoreReferences() {
+	refs, err := rmr.policyManager.SweepMessagePayloads(time.Now())
+	if err != nil {
+		return
+	}
+	for _, ref := range refs {
+		rmr.expireReference(ref)
+	}
+}
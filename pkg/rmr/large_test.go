@@ -0,0 +1,87 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package rmr
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/xapp-frame/pkg/xapp"
+	"github.com/stretchr/testify/assert"
+)
+
+func reassemble(t *testing.T, messages [][]byte) []byte {
+	var payload bytes.Buffer
+	for index, message := range messages {
+		var fragment MessageFragment
+		assert.Nil(t, json.Unmarshal(message, &fragment))
+		assert.Equal(t, index, fragment.Fragment.Index)
+		assert.Equal(t, len(messages), fragment.Fragment.Count)
+		payload.Write(fragment.Data)
+	}
+	return payload.Bytes()
+}
+
+func TestFragmentPayload(t *testing.T) {
+	payload := []byte(`{"cells":["` + strings.Repeat(`cell-"0001",`, 200) + `"]}`)
+
+	fragments, err := fragmentPayload("0123456789abcdef", payload, 512)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 13, len(fragments))
+	for _, fragment := range fragments {
+		assert.LessOrEqual(t, len(fragment), 512)
+	}
+	assert.Equal(t, payload, reassemble(t, fragments))
+
+	_, err = fragmentPayload("0123456789abcdef", payload, 100)
+	assert.NotNil(t, err)
+}
+
+func TestSendLargeMessage(t *testing.T) {
+	transport := NewFakeTransport()
+	sender := &RmrSender{transport: transport, maxSize: 512, largeMessage: LargeMessageFragment}
+	var received []*xapp.RMRParams
+	transport.Handle(MessageTypeID(A1PolicyRequest), func(transport *FakeTransport, params *xapp.RMRParams) {
+		received = append(received, params)
+	})
+	body := `{"operation":"CREATE","policy_type_id":"20001","policy_instance_id":"123456","payload":"` + strings.Repeat("x", 1500) + `","transaction_id":"0123456789abcdef"}`
+
+	assert.True(t, sender.RmrSendToXapp(body, MessageTypeID(A1PolicyRequest), 20001, "0123456789abcdef"))
+
+	var fragments [][]byte
+	for _, params := range received {
+		assert.Equal(t, "0123456789abcdef", params.Xid)
+		assert.Equal(t, 20001, params.SubId)
+		fragments = append(fragments, params.Payload)
+	}
+	assert.Equal(t, 9, len(fragments))
+	assert.Equal(t, body, string(reassemble(t, fragments)))
+
+	received = nil
+	sender.largeMessage = LargeMessageNone
+	assert.False(t, sender.RmrSendToXapp(body, MessageTypeID(A1PolicyRequest), 20001, "0123456789abcdef"))
+	assert.True(t, sender.RmrSendToXapp(`{"operation":"DELETE"}`, MessageTypeID(A1PolicyRequest), 20001, ""))
+	assert.Equal(t, 1, len(received))
+}
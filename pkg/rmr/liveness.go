@@ -0,0 +1,89 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package rmr
+
+import (
+	"fmt"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/metrics"
+)
+
+func policyTypeIdsOf(result map[string]interface{}) []int {
+	var policyTypeIds []int
+	if ids, ok := result["policy_type_ids"].([]interface{}); ok {
+		for _, policyTypeId := range ids {
+			policyTypeIds = append(policyTypeIds, int(policyTypeId.(float64)))
+		}
+	}
+	return policyTypeIds
+}
+
+// handleHandlerHeartbeat records that a handler is alive, for the policy types
+// it lists or for every policy type it is known to handle
+func (rmr *RmrSender) handleHandlerHeartbeat(job *rmrJob) error {
+	handlerId := job.result["handler_id"].(string)
+	a1.Logger.Debug("Recived heartbeat from %s", handlerId)
+	if err := rmr.policyManager.RecordHandlerHeartbeat(handlerId, policyTypeIdsOf(job.result)); err != nil {
+		a1.Logger.Error("failed to record heartbeat of %s : %v", handlerId, err)
+		return err
+	}
+	return nil
+}
+
+// startLivenessMonitor checks the handler heartbeats at every interval
+func (rmr *RmrSender) startLivenessMonitor(timeout time.Duration, interval time.Duration) {
+	a1.Logger.Info("handlers silent for %v are lost, checked every %v", timeout, interval)
+	ticker := time.NewTicker(interval)
+	go func() {
+		for now := range ticker.C {
+			rmr.checkHandlerLiveness(timeout, now)
+		}
+	}()
+}
+
+// checkHandlerLiveness reports the instances enforced by the handlers silent
+// for longer than the timeout as not enforced, and notifies the instances whose
+// enforce status changed
+func (rmr *RmrSender) checkHandlerLiveness(timeout time.Duration, now time.Time) {
+	lostHandlers, err := rmr.policyManager.LostPolicyTypeHandlers(timeout, now)
+	if err != nil {
+		a1.Logger.Error("failed to check the policy type handlers : %v", err)
+		return
+	}
+	for _, lost := range lostHandlers {
+		detail := fmt.Sprintf("no heartbeat from %s since %s", lost.HandlerId, lost.LastHeartbeat)
+		instances, err := rmr.policyManager.MarkPolicyTypeHandlerLost(lost, detail)
+		if err != nil {
+			a1.Logger.Error("failed to mark handler %s of policy type %d lost : %v", lost.HandlerId, lost.PolicyTypeId, err)
+			continue
+		}
+		metrics.IncCounter(metrics.LostPolicyHandler)
+		for _, policyInstanceId := range instances {
+			err = rmr.policyManager.SendPolicyStatusNotification(lost.PolicyTypeId, policyInstanceId, lost.HandlerId, "ERROR")
+			if err != nil {
+				a1.Logger.Debug("failed to send policy status notification %v+", err)
+			}
+		}
+	}
+}
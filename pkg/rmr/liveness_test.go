@@ -0,0 +1,52 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package rmr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateHandlerHeartbeat(t *testing.T) {
+	result, err := validateMessage(A1HandlerHeartbeat, []byte(`{"handler_id":"qp","policy_type_ids":[20001,20002]}`))
+	assert.Nil(t, err)
+	assert.Equal(t, []int{20001, 20002}, policyTypeIdsOf(result))
+
+	result, err = validateMessage(A1HandlerHeartbeat, []byte(`{"handler_id":"qp"}`))
+	assert.Nil(t, err)
+	assert.Nil(t, policyTypeIdsOf(result))
+
+	// unknown fields are ignored
+	_, err = validateMessage(A1HandlerHeartbeat, []byte(`{"handler_id":"qp","status":"OK"}`))
+	assert.Nil(t, err)
+
+	for _, payload := range []string{
+		`{}`,
+		`{"handler_id":""}`,
+		`{"handler_id":"qp","policy_type_ids":[0]}`,
+		`{"handler_id":7}`,
+	} {
+		_, err := validateMessage(A1HandlerHeartbeat, []byte(payload))
+		assert.NotNil(t, err, payload)
+	}
+}
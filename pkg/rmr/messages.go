@@ -21,20 +21,57 @@
 package rmr
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 
 	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/policy"
 )
 
+const (
+	// PolicyMessageVersionLegacy is the policy message carrying only the
+	// operation, the policy type, the policy instance and the payload
+	PolicyMessageVersionLegacy = 1
+	// PolicyMessageVersion is the current policy message format
+	PolicyMessageVersion = 2
+)
+
+var missingTransactionError = errors.New("policy message without transaction")
+
 type Message struct {
+	// Version is the format of the policy messages, the current one when unset
+	Version int
 }
 
-func (m *Message) PolicyMessage(policyTypeId string, policyInstanceID string, httpBody string, operation string) (string, error) {
-	var datajson interface{}
-	datajson = map[string]string{"operation": operation,
+// NewTransactionID generates the id correlating an A1_POLICY_REQ with the
+// A1_POLICY_RESP messages of the xApps
+func NewTransactionID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		a1.Logger.Error("failed to generate transaction id : %v", err)
+	}
+	return hex.EncodeToString(b)
+}
+
+func (m *Message) PolicyMessage(policyTypeId string, policyInstanceID string, httpBody string, operation string, transaction *policy.PolicyTransaction) (string, error) {
+	datajson := map[string]interface{}{"operation": operation,
 		"policy_type_id":     policyTypeId,
 		"policy_instance_id": policyInstanceID,
 		"payload":            httpBody}
+	if m.Version != PolicyMessageVersionLegacy {
+		if transaction == nil {
+			a1.Logger.Error("no transaction for the %s of policy instance %s.%s", operation, policyTypeId, policyInstanceID)
+			return "", missingTransactionError
+		}
+		datajson["version"] = PolicyMessageVersion
+		datajson["transaction_id"] = transaction.TransactionID
+		datajson["revision"] = transaction.Revision
+		datajson["sequence"] = transaction.Sequence
+		datajson["created_at"] = transaction.CreatedAt
+		datajson["updated_at"] = transaction.UpdatedAt
+	}
 	data, err := json.Marshal(datajson)
 	if err != nil {
 		a1.Logger.Error("marshal error : %v", err)
@@ -0,0 +1,88 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package rmr
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/policy"
+	"github.com/stretchr/testify/assert"
+)
+
+var transactionFields = []string{"version", "transaction_id", "revision", "sequence", "created_at", "updated_at"}
+
+func policyTransaction() *policy.PolicyTransaction {
+	return &policy.PolicyTransaction{
+		TransactionID: "0123456789abcdef",
+		Revision:      3,
+		Sequence:      7,
+		Operation:     "UPDATE",
+		CreatedAt:     "2022-11-02 10:30:20",
+		UpdatedAt:     "2022-11-02 10:31:20",
+	}
+}
+
+func TestPolicyMessage(t *testing.T) {
+	message := Message{Version: PolicyMessageVersion}
+	data, err := message.PolicyMessage("20001", "123456", `{"enforce":true}`, "UPDATE", policyTransaction())
+	assert.NoError(t, err)
+
+	var result map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(data), &result))
+	for _, field := range transactionFields {
+		assert.Contains(t, result, field)
+	}
+	assert.Equal(t, float64(PolicyMessageVersion), result["version"])
+	assert.Equal(t, "0123456789abcdef", result["transaction_id"])
+	assert.Equal(t, float64(3), result["revision"])
+	assert.Equal(t, float64(7), result["sequence"])
+	assert.Equal(t, "2022-11-02 10:30:20", result["created_at"])
+	assert.Equal(t, "2022-11-02 10:31:20", result["updated_at"])
+	assert.Equal(t, "UPDATE", result["operation"])
+	assert.Equal(t, "20001", result["policy_type_id"])
+	assert.Equal(t, "123456", result["policy_instance_id"])
+}
+
+func TestPolicyMessageLegacy(t *testing.T) {
+	message := Message{Version: PolicyMessageVersionLegacy}
+	data, err := message.PolicyMessage("20001", "123456", `{"enforce":true}`, "CREATE", policyTransaction())
+	assert.NoError(t, err)
+
+	var result map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(data), &result))
+	for _, field := range transactionFields {
+		assert.NotContains(t, result, field)
+	}
+	assert.Equal(t, 4, len(result))
+}
+
+func TestPolicyMessageWithoutTransaction(t *testing.T) {
+	message := Message{}
+	_, err := message.PolicyMessage("20001", "123456", `{"enforce":true}`, "CREATE", nil)
+	assert.Equal(t, missingTransactionError, err)
+
+	// the legacy format carries no transaction
+	message.Version = PolicyMessageVersionLegacy
+	_, err = message.PolicyMessage("20001", "123456", `{"enforce":true}`, "CREATE", nil)
+	assert.NoError(t, err)
+}
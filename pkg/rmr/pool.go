@@ -0,0 +1,121 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+package rmr
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/metrics"
+	"gerrit.o-ran-sc.org/r/ric-plt/xapp-frame/pkg/xapp"
+)
+
+// rmrJob is a validated message waiting to be processed
+type rmrJob struct {
+	name     string
+	msg      *xapp.RMRParams
+	result   map[string]interface{}
+	received time.Time
+}
+
+// workerPool processes the received messages concurrently. Messages with the
+// same ordering key always go to the same worker, which processes them in the
+// order they were received.
+type workerPool struct {
+	queues []chan *rmrJob
+	handle func(*rmrJob)
+}
+
+func newWorkerPool(workers int, queueSize int, handle func(*rmrJob)) *workerPool {
+	pool := &workerPool{
+		queues: make([]chan *rmrJob, workers),
+		handle: handle,
+	}
+	for i := range pool.queues {
+		pool.queues[i] = make(chan *rmrJob, queueSize)
+		go pool.work(pool.queues[i])
+	}
+	return pool
+}
+
+// dispatch queues the job to the worker of its ordering key. Unless block is
+// set, it returns false without queueing the job when the queue of that worker is
+// full, so that a slow worker does not stop the receiver.
+func (p *workerPool) dispatch(key string, job *rmrJob, block bool) bool {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	queue := p.queues[h.Sum32()%uint32(len(p.queues))]
+	if block {
+		queue <- job
+		metrics.AddGauge(metrics.RmrQueueDepth, 1)
+		return true
+	}
+	select {
+	case queue <- job:
+		metrics.AddGauge(metrics.RmrQueueDepth, 1)
+		return true
+	default:
+		metrics.IncCounter(metrics.RmrQueueFull)
+		return false
+	}
+}
+
+func (p *workerPool) work(queue chan *rmrJob) {
+	for job := range queue {
+		metrics.AddGauge(metrics.RmrQueueDepth, -1)
+		p.handle(job)
+	}
+}
+
+// isEiMessage tells whether the message is about EI jobs, whose handling waits
+// for the ECS and is therefore done by workers of its own
+func isEiMessage(name string) bool {
+	switch name {
+	case A1EiQueryAll, A1EiCreateJob, A1EiQueryJob, A1EiUpdateJob, A1EiDeleteJob, A1EiQueryJobs:
+		return true
+	}
+	return false
+}
+
+// messageOrderingKey tells which messages must be processed in order: the
+// responses and the feedback about one policy instance, the queries about one
+// policy type, the heartbeats of one handler, the messages about one EI job and
+// the job queries of one owner
+func messageOrderingKey(name string, result map[string]interface{}) string {
+	switch name {
+	case A1PolicyResponse:
+		return fmt.Sprintf("%v.%v", result["policy_type_id"], result["policy_instance_id"])
+	case A1PolicyQuery:
+		if policyTypeId, ok := result["policy_type_id"]; ok {
+			return fmt.Sprintf("%v", policyTypeId)
+		}
+	case A1PolicyFeedback:
+		return fmt.Sprintf("%s.%v.%v", name, result["policy_type_id"], result["policy_instance_id"])
+	case A1HandlerHeartbeat:
+		return fmt.Sprintf("%s.%v", name, result["handler_id"])
+	case A1EiCreateJob, A1EiQueryJob, A1EiUpdateJob, A1EiDeleteJob:
+		return fmt.Sprintf("A1_EI_JOB.%v", result["job-id"])
+	case A1EiQueryJobs:
+		return fmt.Sprintf("%s.%v", name, result["owner"])
+	}
+	return name
+}
@@ -0,0 +1,110 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package rmr
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkerPoolOrdering(t *testing.T) {
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	handled := map[string][]int{}
+	pool := newWorkerPool(4, 60, func(job *rmrJob) {
+		defer wg.Done()
+		mutex.Lock()
+		defer mutex.Unlock()
+		key := messageOrderingKey(job.name, job.result)
+		handled[key] = append(handled[key], int(job.result["revision"].(float64)))
+	})
+	for revision := 1; revision <= 20; revision++ {
+		for _, instance := range []string{"1", "2", "3"} {
+			wg.Add(1)
+			result := map[string]interface{}{"policy_type_id": float64(20001), "policy_instance_id": instance, "revision": float64(revision)}
+			assert.True(t, pool.dispatch(messageOrderingKey("A1_POLICY_RESP", result), &rmrJob{name: "A1_POLICY_RESP", result: result, received: time.Now()}, false))
+		}
+	}
+	wg.Wait()
+
+	assert.Equal(t, 3, len(handled))
+	for _, revisions := range handled {
+		assert.Equal(t, 20, len(revisions))
+		for i, revision := range revisions {
+			assert.Equal(t, i+1, revision)
+		}
+	}
+}
+
+func TestWorkerPoolQueueFull(t *testing.T) {
+	started := make(chan bool)
+	release := make(chan bool)
+	var wg sync.WaitGroup
+	pool := newWorkerPool(1, 1, func(job *rmrJob) {
+		defer wg.Done()
+		if job.name == "first" {
+			started <- true
+			<-release
+		}
+	})
+	wg.Add(2)
+	assert.True(t, pool.dispatch("key", &rmrJob{name: "first", received: time.Now()}, false))
+	<-started
+	assert.True(t, pool.dispatch("key", &rmrJob{name: "second", received: time.Now()}, false))
+	assert.False(t, pool.dispatch("key", &rmrJob{name: "third", received: time.Now()}, false))
+
+	// a blocking dispatch waits for room in the queue instead
+	wg.Add(1)
+	queued := make(chan bool)
+	go func() {
+		queued <- pool.dispatch("key", &rmrJob{name: "fourth", received: time.Now()}, true)
+	}()
+	select {
+	case <-queued:
+		t.Error("blocking dispatch returned while the queue was full")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(release)
+	assert.True(t, <-queued)
+	wg.Wait()
+}
+
+func TestIsEiMessage(t *testing.T) {
+	assert.True(t, isEiMessage(A1EiCreateJob))
+	assert.True(t, isEiMessage(A1EiQueryAll))
+	assert.False(t, isEiMessage(A1PolicyResponse))
+	assert.False(t, isEiMessage(A1HandlerHeartbeat))
+}
+
+func TestMessageOrderingKey(t *testing.T) {
+	assert.Equal(t, "20001.123456", messageOrderingKey("A1_POLICY_RESP", map[string]interface{}{"policy_type_id": float64(20001), "policy_instance_id": "123456"}))
+	assert.Equal(t, "20001", messageOrderingKey("A1_POLICY_QUERY", map[string]interface{}{"policy_type_id": float64(20001)}))
+	assert.Equal(t, "A1_POLICY_FEEDBACK.20001.123456", messageOrderingKey("A1_POLICY_FEEDBACK", map[string]interface{}{"policy_type_id": float64(20001), "policy_instance_id": "123456"}))
+	assert.Equal(t, "A1_HANDLER_HEARTBEAT.qp", messageOrderingKey("A1_HANDLER_HEARTBEAT", map[string]interface{}{"handler_id": "qp"}))
+	assert.Equal(t, "A1_EI_JOB.1", messageOrderingKey("A1_EI_CREATE_JOB", map[string]interface{}{"job-id": float64(1)}))
+	assert.Equal(t, "A1_EI_JOB.1", messageOrderingKey("A1_EI_DELETE_JOB", map[string]interface{}{"job-id": float64(1)}))
+	assert.Equal(t, "A1_EI_QUERY_JOBS.xapp1", messageOrderingKey("A1_EI_QUERY_JOBS", map[string]interface{}{"owner": "xapp1"}))
+	assert.Equal(t, "A1_EI_QUERY_ALL", messageOrderingKey("A1_EI_QUERY_ALL", nil))
+}
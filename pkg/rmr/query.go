@@ -0,0 +1,222 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package rmr
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/metrics"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/models"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/policy"
+	"gerrit.o-ran-sc.org/r/ric-plt/xapp-frame/pkg/xapp"
+)
+
+// policyQuery is an A1_POLICY_QUERY. It asks for one instance when it has a
+// policy instance id, for the instances of the listed types when it has type
+// ids, and for the instances of every type otherwise.
+type policyQuery struct {
+	requester        string
+	policyTypeIds    []int
+	policyInstanceId string
+}
+
+func parsePolicyQuery(msg *xapp.RMRParams, result map[string]interface{}) *policyQuery {
+	query := &policyQuery{requester: msg.Src}
+	if handlerId, ok := result["handler_id"].(string); ok && len(handlerId) > 0 {
+		query.requester = handlerId
+	}
+	if policyTypeId, ok := result["policy_type_id"].(float64); ok {
+		query.policyTypeIds = append(query.policyTypeIds, int(policyTypeId))
+	}
+	if policyTypeIds, ok := result["policy_type_ids"].([]interface{}); ok {
+		for _, policyTypeId := range policyTypeIds {
+			query.policyTypeIds = append(query.policyTypeIds, int(policyTypeId.(float64)))
+		}
+	}
+	query.policyInstanceId, _ = result["policy_instance_id"].(string)
+	return query
+}
+
+// scope identifies the instances the query asks for
+func (q *policyQuery) scope() string {
+	if len(q.policyTypeIds) == 0 {
+		return "*"
+	}
+	return fmt.Sprintf("%v.%s", q.policyTypeIds, q.policyInstanceId)
+}
+
+// queryLimiter drops the queries repeated by an xApp within the minimum interval
+type queryLimiter struct {
+	mutex    sync.Mutex
+	interval time.Duration
+	last     map[string]time.Time
+}
+
+func newQueryLimiter(interval time.Duration) *queryLimiter {
+	return &queryLimiter{interval: interval, last: map[string]time.Time{}}
+}
+
+func (l *queryLimiter) allow(key string, now time.Time) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	for k, last := range l.last {
+		if now.Sub(last) >= l.interval {
+			delete(l.last, k)
+		}
+	}
+	if _, ok := l.last[key]; ok {
+		return false
+	}
+	l.last[key] = now
+	return true
+}
+
+// policyQueryEnd is sent once all the instances asked by a query are sent
+type policyQueryEnd struct {
+	HandlerId        string `json:"handler_id,omitempty"`
+	PolicyTypeIds    []int  `json:"policy_type_ids"`
+	PolicyInstanceId string `json:"policy_instance_id,omitempty"`
+	Sent             int    `json:"sent"`
+	Failed           int    `json:"failed"`
+}
+
+// handlePolicyQuery sends the queried policy instances to the xApps, followed by
+// an A1_POLICY_QUERY_END. Instances being deleted or waiting for their validity
+// window are skipped like in a resync. An instance that cannot be read or sent is
+// counted as failed without stopping the others. A query naming its handler
+// counts as a heartbeat of the handler.
+func (rmr *RmrSender) handlePolicyQuery(job *rmrJob) error {
+	query := parsePolicyQuery(job.msg, job.result)
+	a1.Logger.Debug("Recived policy query from %s for %s", query.requester, query.scope())
+	if handlerId, ok := job.result["handler_id"].(string); ok && len(handlerId) > 0 {
+		if err := rmr.policyManager.RecordHandlerHeartbeat(handlerId, query.policyTypeIds); err != nil {
+			a1.Logger.Error("failed to record heartbeat of %s : %v", handlerId, err)
+		}
+	}
+	if rmr.queryLimiter != nil && !rmr.queryLimiter.allow(query.requester+"/"+query.scope(), time.Now()) {
+		a1.Logger.Warning("ignoring policy query repeated by %s for %s", query.requester, query.scope())
+		metrics.IncCounter(metrics.ThrottledPolicyQuery)
+		return nil
+	}
+	policyTypeIds := query.policyTypeIds
+	if len(policyTypeIds) == 0 {
+		var err error
+		if policyTypeIds, err = rmr.policyManager.GetAllPolicyType(); err != nil {
+			a1.Logger.Error("Error : %+v", err)
+			return err
+		}
+	}
+
+	end := policyQueryEnd{HandlerId: query.requester, PolicyTypeIds: policyTypeIds, PolicyInstanceId: query.policyInstanceId}
+	for _, policyTypeId := range policyTypeIds {
+		instanceList := []models.PolicyInstanceID{models.PolicyInstanceID(query.policyInstanceId)}
+		if len(query.policyInstanceId) == 0 {
+			var err error
+			instanceList, err = rmr.policyManager.GetAllPolicyInstance(policyTypeId)
+			if err != nil && !policy.IsPolicyInstanceNotFound(err) {
+				a1.Logger.Error("failed to get the instances of policy type %d : %v", policyTypeId, err)
+				end.Failed++
+				continue
+			}
+		}
+		for _, policyInstanceId := range instanceList {
+			active, err := rmr.policyManager.IsPolicyInstanceActive(policyTypeId, string(policyInstanceId))
+			if err != nil {
+				a1.Logger.Error("failed to get the state of policy instance %d.%s : %v", policyTypeId, policyInstanceId, err)
+				end.Failed++
+				continue
+			}
+			if !active {
+				a1.Logger.Debug("policy instance %d.%s is not active, not sent", policyTypeId, policyInstanceId)
+				continue
+			}
+			if err := rmr.sendPolicyInstance(policyTypeId, policyInstanceId); err != nil {
+				a1.Logger.Error("failed to send policy instance %d.%s : %v", policyTypeId, policyInstanceId, err)
+				end.Failed++
+				continue
+			}
+			end.Sent++
+		}
+	}
+	rmr.sendPolicyQueryEnd(query, end)
+	return nil
+}
+
+// sendPolicyInstance sends the instance again as its outstanding request
+func (rmr *RmrSender) sendPolicyInstance(policyTypeId int, policyInstanceId models.PolicyInstanceID) error {
+	policyinstance, err := rmr.policyManager.GetPolicyInstance(models.PolicyTypeID(policyTypeId), policyInstanceId)
+	if err != nil {
+		return err
+	}
+	policyinstanceString, ok := policyinstance.(string)
+	if !ok {
+		return fmt.Errorf("policy instance %d.%s is not a string", policyTypeId, policyInstanceId)
+	}
+	transaction, err := rmr.policyManager.GetPolicyTransaction(policyTypeId, string(policyInstanceId))
+	if err != nil {
+		return err
+	}
+	if transaction == nil {
+		transaction = &policy.PolicyTransaction{}
+	}
+	message := Message{Version: rmr.messageVersion}
+	rmrMessage, err := message.PolicyMessage(strconv.Itoa(policyTypeId), string(policyInstanceId), policyinstanceString, "CREATE", transaction)
+	if err != nil {
+		return err
+	}
+	if len(transaction.RanNames) == 0 {
+		if !rmr.RmrSendToXapp(rmrMessage, MessageTypeID(A1PolicyRequest), policyTypeId, transaction.TransactionID) {
+			return fmt.Errorf("message not sent")
+		}
+		return nil
+	}
+	for _, ranName := range transaction.RanNames {
+		if !rmr.RmrSendToNode(rmrMessage, MessageTypeID(A1PolicyRequest), policyTypeId, ranName, transaction.TransactionID) {
+			return fmt.Errorf("message not sent to %s", ranName)
+		}
+	}
+	return nil
+}
+
+// sendPolicyQueryEnd tells the xApp that all the instances it queried are sent.
+// It is routed with the queried type as subscription id when there is only one.
+func (rmr *RmrSender) sendPolicyQueryEnd(query *policyQuery, end policyQueryEnd) {
+	payload, err := json.Marshal(end)
+	if err != nil {
+		a1.Logger.Error("marshal error : %v", err)
+		return
+	}
+	subId := DefaultSubId
+	if len(query.policyTypeIds) == 1 {
+		subId = query.policyTypeIds[0]
+	}
+	if rmr.RmrSendToXapp(string(payload), MessageTypeID(A1PolicyQueryEnd), subId, "") {
+		a1.Logger.Debug("policy query of %s for %s answered, %d instances sent and %d failed", query.requester, query.scope(), end.Sent, end.Failed)
+	} else {
+		a1.Logger.Error("rmrSendToXapp : end of policy query for %s not sent", query.requester)
+	}
+}
@@ -0,0 +1,190 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package rmr
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/policy"
+	"gerrit.o-ran-sc.org/r/ric-plt/xapp-frame/pkg/xapp"
+	"github.com/stretchr/testify/assert"
+)
+
+// memSdl keeps the data in memory and returns each key's own content
+type memSdl map[string]interface{}
+
+func (s memSdl) Set(ns string, pairs ...interface{}) error {
+	for i := 0; i+1 < len(pairs); i += 2 {
+		s[pairs[i].(string)] = pairs[i+1]
+	}
+	return nil
+}
+
+func (s memSdl) SetIf(ns string, key string, oldData, newData interface{}) (bool, error) {
+	if s[key] != oldData {
+		return false, nil
+	}
+	s[key] = newData
+	return true, nil
+}
+
+func (s memSdl) Get(ns string, keys []string) (map[string]interface{}, error) {
+	mp := map[string]interface{}{}
+	for _, key := range keys {
+		if value, ok := s[key]; ok {
+			mp[key] = value
+		}
+	}
+	return mp, nil
+}
+
+func (s memSdl) GetAll(ns string) ([]string, error) {
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (s memSdl) Remove(ns string, keys []string) error {
+	for _, key := range keys {
+		delete(s, key)
+	}
+	return nil
+}
+
+func TestParsePolicyQuery(t *testing.T) {
+	msg := &xapp.RMRParams{Src: "service-ricxapp-qp:4560"}
+	query := parsePolicyQuery(msg, map[string]interface{}{"policy_type_id": float64(20001), "policy_instance_id": "123456"})
+	assert.Equal(t, "service-ricxapp-qp:4560", query.requester)
+	assert.Equal(t, []int{20001}, query.policyTypeIds)
+	assert.Equal(t, "123456", query.policyInstanceId)
+
+	query = parsePolicyQuery(msg, map[string]interface{}{"policy_type_ids": []interface{}{float64(20001), float64(20002)}, "handler_id": "qp"})
+	assert.Equal(t, "qp", query.requester)
+	assert.Equal(t, []int{20001, 20002}, query.policyTypeIds)
+	assert.Equal(t, "", query.policyInstanceId)
+
+	query = parsePolicyQuery(msg, nil)
+	assert.Nil(t, query.policyTypeIds)
+	assert.Equal(t, "*", query.scope())
+}
+
+func TestQueryLimiter(t *testing.T) {
+	limiter := newQueryLimiter(10 * time.Second)
+	now := time.Now()
+	assert.True(t, limiter.allow("qp/*", now))
+	assert.False(t, limiter.allow("qp/*", now.Add(5*time.Second)))
+	assert.True(t, limiter.allow("qp/[20001].", now.Add(5*time.Second)))
+	assert.True(t, limiter.allow("qp/*", now.Add(10*time.Second)))
+}
+
+func TestValidatePolicyQuery(t *testing.T) {
+	for _, payload := range []string{
+		`{}`,
+		`{"policy_type_id":20001,"policy_instance_id":"123456"}`,
+		`{"policy_type_ids":[20001,20002],"handler_id":"qp"}`,
+	} {
+		_, err := validateMessage(A1PolicyQuery, []byte(payload))
+		assert.Nil(t, err, payload)
+	}
+	for _, payload := range []string{
+		`{"policy_instance_id":"123456"}`,
+		`{"policy_type_id":20001,"policy_type_ids":[20002]}`,
+		`{"policy_type_ids":["20001"]}`,
+	} {
+		_, err := validateMessage(A1PolicyQuery, []byte(payload))
+		assert.NotNil(t, err, payload)
+	}
+}
+
+func queryInstanceStore() memSdl {
+	return memSdl{
+		"a1.policy_type.20001":                    `{"policy_type_id":20001}`,
+		"a1.policy_instance.20001.active":         `{"enforce":true}`,
+		"a1.policy_inst_metadata.20001.active":    `[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"ENFORCED"}]`,
+		"a1.policy_instance.20001.deleting":       `{"enforce":true}`,
+		"a1.policy_inst_metadata.20001.deleting":  `[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"DELETING"}]`,
+		"a1.policy_instance.20001.deleted":        `{"enforce":true}`,
+		"a1.policy_inst_metadata.20001.deleted":   `{"created_at":"2022-11-02 10:30:20","has_been_deleted":"True","state":"DELETED"}`,
+		"a1.policy_instance.20001.scheduled":      `{"enforce":true}`,
+		"a1.policy_inst_metadata.20001.scheduled": `[{"created_at":"2022-11-02 10:30:20","has_been_deleted":"False","state":"PENDING"}]`,
+		"a1.policy_schedule.20001.scheduled":      `{"valid_from":"2099-01-01T00:00:00Z","status":"PENDING"}`,
+	}
+}
+
+func queryPolicyInstances(t *testing.T, result map[string]interface{}) ([]string, policyQueryEnd) {
+	transport := NewFakeTransport()
+	sender := &RmrSender{transport: transport, policyManager: policy.NewPolicyManager(queryInstanceStore())}
+	var sent []string
+	var end policyQueryEnd
+	transport.Handle(MessageTypeID(A1PolicyRequest), func(transport *FakeTransport, params *xapp.RMRParams) {
+		var request map[string]interface{}
+		assert.Nil(t, json.Unmarshal(params.Payload, &request))
+		sent = append(sent, request["policy_instance_id"].(string))
+	})
+	transport.Handle(MessageTypeID(A1PolicyQueryEnd), func(transport *FakeTransport, params *xapp.RMRParams) {
+		assert.Nil(t, json.Unmarshal(params.Payload, &end))
+	})
+
+	assert.Nil(t, sender.handlePolicyQuery(&rmrJob{name: A1PolicyQuery, msg: &xapp.RMRParams{Src: "qp"}, result: result}))
+	return sent, end
+}
+
+func TestHandlePolicyQuery(t *testing.T) {
+	sent, end := queryPolicyInstances(t, map[string]interface{}{"policy_type_id": float64(20001)})
+
+	assert.Equal(t, []string{"active"}, sent)
+	assert.Equal(t, 1, end.Sent)
+	assert.Equal(t, 0, end.Failed)
+
+	sent, end = queryPolicyInstances(t, map[string]interface{}{"policy_type_id": float64(20001), "policy_instance_id": "active"})
+
+	assert.Equal(t, []string{"active"}, sent)
+	assert.Equal(t, 1, end.Sent)
+}
+
+func TestHandlePolicyQueryDeletingInstance(t *testing.T) {
+	sent, end := queryPolicyInstances(t, map[string]interface{}{"policy_type_id": float64(20001), "policy_instance_id": "deleting"})
+
+	assert.Nil(t, sent)
+	assert.Equal(t, 0, end.Sent)
+	assert.Equal(t, 0, end.Failed)
+}
+
+func TestHandlePolicyQueryDeletedInstance(t *testing.T) {
+	sent, end := queryPolicyInstances(t, map[string]interface{}{"policy_type_id": float64(20001), "policy_instance_id": "deleted"})
+
+	assert.Nil(t, sent)
+	assert.Equal(t, 0, end.Sent)
+	assert.Equal(t, 0, end.Failed)
+}
+
+func TestHandlePolicyQueryPendingSchedule(t *testing.T) {
+	sent, end := queryPolicyInstances(t, map[string]interface{}{"policy_type_id": float64(20001), "policy_instance_id": "scheduled"})
+
+	assert.Nil(t, sent)
+	assert.Equal(t, 0, end.Sent)
+	assert.Equal(t, 0, end.Failed)
+}
@@ -0,0 +1,195 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+package rmr
+
+import (
+	"fmt"
+	"sync"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/config"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
+)
+
+// names of the RMR message types exchanged with the xApps
+const (
+	A1PolicyRequest    = "A1_POLICY_REQ"
+	A1PolicyResponse   = "A1_POLICY_RESP"
+	A1PolicyQuery      = "A1_POLICY_QUERY"
+	A1PolicyQueryEnd   = "A1_POLICY_QUERY_END"
+	A1EiQueryAll       = "A1_EI_QUERY_ALL"
+	A1EiQueryAllResp   = "A1_EI_QUERY_ALL_RESP"
+	A1EiCreateJob      = "A1_EI_CREATE_JOB"
+	A1EiCreateJobResp  = "A1_EI_CREATE_JOB_RESP"
+	A1EiDataDelivery   = "A1_EI_DATA_DELIVERY"
+	A1HandlerHeartbeat = "A1_HANDLER_HEARTBEAT"
+	A1PolicyFeedback   = "A1_POLICY_FEEDBACK"
+	A1EiError          = "A1_EI_ERROR"
+	A1EiQueryJob       = "A1_EI_QUERY_JOB"
+	A1EiQueryJobResp   = "A1_EI_QUERY_JOB_RESP"
+	A1EiUpdateJob      = "A1_EI_UPDATE_JOB"
+	A1EiUpdateJobResp  = "A1_EI_UPDATE_JOB_RESP"
+	A1EiDeleteJob      = "A1_EI_DELETE_JOB"
+	A1EiDeleteJobResp  = "A1_EI_DELETE_JOB_RESP"
+	A1EiQueryJobs      = "A1_EI_QUERY_JOBS"
+	A1EiQueryJobsResp  = "A1_EI_QUERY_JOBS_RESP"
+)
+
+// UnknownMessageType is the id of a message type missing from the registry
+const UnknownMessageType = -1
+
+type messageHandler func(rmr *RmrSender, job *rmrJob) error
+
+// builtinMessageType is a message type known to the mediator, with the handler
+// processing it when received from the xApps. Types only sent have no handler.
+type builtinMessageType struct {
+	id      int
+	handler messageHandler
+}
+
+var defaultMessageTypes = map[string]builtinMessageType{
+	A1PolicyRequest:    {id: 20010},
+	A1PolicyResponse:   {id: 20011, handler: (*RmrSender).handlePolicyResponse},
+	A1PolicyQuery:      {id: 20012, handler: (*RmrSender).handlePolicyQuery},
+	A1EiQueryAll:       {id: 20013, handler: (*RmrSender).handleEiQueryAll},
+	A1EiQueryAllResp:   {id: 20014},
+	A1EiCreateJob:      {id: 20015, handler: (*RmrSender).handleEiCreateJob},
+	A1EiCreateJobResp:  {id: 20016},
+	A1EiDataDelivery:   {id: 20017},
+	A1PolicyQueryEnd:   {id: 20018},
+	A1HandlerHeartbeat: {id: 20019, handler: (*RmrSender).handleHandlerHeartbeat},
+	A1PolicyFeedback:   {id: 20020, handler: (*RmrSender).handlePolicyFeedback},
+	A1EiError:          {id: 20021},
+	A1EiQueryJob:       {id: 20022, handler: (*RmrSender).handleEiQueryJob},
+	A1EiQueryJobResp:   {id: 20023},
+	A1EiUpdateJob:      {id: 20024, handler: (*RmrSender).handleEiUpdateJob},
+	A1EiUpdateJobResp:  {id: 20025},
+	A1EiDeleteJob:      {id: 20026, handler: (*RmrSender).handleEiDeleteJob},
+	A1EiDeleteJobResp:  {id: 20027},
+	A1EiQueryJobs:      {id: 20028, handler: (*RmrSender).handleEiQueryJobs},
+	A1EiQueryJobsResp:  {id: 20029},
+}
+
+// messageTypeRegistry maps the names of the RMR message types to their ids both
+// ways, and to the handlers of the types received from the xApps
+type messageTypeRegistry struct {
+	mutex    sync.RWMutex
+	ids      map[string]int
+	names    map[int]string
+	handlers map[string]messageHandler
+}
+
+// messageTypes is set up in init, as the handlers of the built-in types look
+// their ids up in it
+var messageTypes *messageTypeRegistry
+
+func init() {
+	messageTypes = newMessageTypeRegistry(defaultMessageTypes)
+}
+
+func newMessageTypeRegistry(types map[string]builtinMessageType) *messageTypeRegistry {
+	registry := &messageTypeRegistry{
+		ids:      map[string]int{},
+		names:    map[int]string{},
+		handlers: map[string]messageHandler{},
+	}
+	for name, messageType := range types {
+		registry.register(name, messageType.id, messageType.handler)
+	}
+	return registry
+}
+
+// register adds a message type or moves a known one to another id, keeping its
+// handler when none is given. An id already used by another type is rejected.
+func (r *messageTypeRegistry) register(name string, id int, handler messageHandler) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if other, ok := r.names[id]; ok && other != name {
+		return fmt.Errorf("RMR message type id %d of %s is already used by %s", id, name, other)
+	}
+	if previous, ok := r.ids[name]; ok {
+		delete(r.names, previous)
+	}
+	r.ids[name] = id
+	r.names[id] = name
+	if handler != nil {
+		r.handlers[name] = handler
+	}
+	return nil
+}
+
+func (r *messageTypeRegistry) id(name string) int {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if id, ok := r.ids[name]; ok {
+		return id
+	}
+	return UnknownMessageType
+}
+
+func (r *messageTypeRegistry) name(id int) string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.names[id]
+}
+
+// handler returns the handler of the named message type, nil for the types
+// not received from the xApps
+func (r *messageTypeRegistry) handler(name string) messageHandler {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.handlers[name]
+}
+
+// RegisterMessageTypes adds the configured message types to the registry. A
+// name or an id configured twice, or an id already used by another type, is
+// rejected.
+func RegisterMessageTypes(types []config.RmrMessageType) error {
+	names := map[string]bool{}
+	ids := map[int]bool{}
+	for _, messageType := range types {
+		if names[messageType.Name] {
+			return fmt.Errorf("RMR message type %s configured twice", messageType.Name)
+		}
+		if ids[messageType.ID] {
+			return fmt.Errorf("RMR message type id %d configured twice", messageType.ID)
+		}
+		names[messageType.Name] = true
+		ids[messageType.ID] = true
+	}
+	for _, messageType := range types {
+		a1.Logger.Info("registering RMR message type %s with id %d", messageType.Name, messageType.ID)
+		if err := messageTypes.register(messageType.Name, messageType.ID, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MessageTypeID returns the id of the named message type
+func MessageTypeID(name string) int {
+	return messageTypes.id(name)
+}
+
+// MessageTypeName returns the name of the message type id, or an empty string
+// when the id is not registered
+func MessageTypeName(id int) string {
+	return messageTypes.name(id)
+}
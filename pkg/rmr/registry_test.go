@@ -0,0 +1,65 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package rmr
+
+import (
+	"testing"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/config"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageTypeRegistry(t *testing.T) {
+	registry := newMessageTypeRegistry(defaultMessageTypes)
+	assert.Equal(t, 20010, registry.id(A1PolicyRequest))
+	assert.Equal(t, A1PolicyResponse, registry.name(20011))
+	assert.Equal(t, UnknownMessageType, registry.id("A1_UNKNOWN"))
+	assert.Equal(t, "", registry.name(30000))
+	assert.NotNil(t, registry.handler(A1PolicyResponse))
+	assert.Nil(t, registry.handler(A1PolicyRequest))
+
+	assert.NoError(t, registry.register("A1_CUSTOM", 30000, nil))
+	assert.Equal(t, "A1_CUSTOM", registry.name(30000))
+	assert.Nil(t, registry.handler("A1_CUSTOM"))
+
+	// a known type moved to another id keeps its handler
+	assert.NoError(t, registry.register(A1PolicyResponse, 30011, nil))
+	assert.Equal(t, 30011, registry.id(A1PolicyResponse))
+	assert.Equal(t, "", registry.name(20011))
+	assert.NotNil(t, registry.handler(A1PolicyResponse))
+
+	assert.Error(t, registry.register("A1_OTHER", 30000, nil))
+	assert.Equal(t, "A1_CUSTOM", registry.name(30000))
+	assert.Equal(t, UnknownMessageType, registry.id("A1_OTHER"))
+}
+
+func TestRegisterMessageTypes(t *testing.T) {
+	assert.Error(t, RegisterMessageTypes([]config.RmrMessageType{{Name: "A1_CUSTOM", ID: 30000}, {Name: "A1_CUSTOM", ID: 30001}}))
+	assert.Error(t, RegisterMessageTypes([]config.RmrMessageType{{Name: "A1_CUSTOM", ID: 30000}, {Name: "A1_OTHER", ID: 30000}}))
+	assert.Equal(t, UnknownMessageType, MessageTypeID("A1_CUSTOM"))
+
+	// a configured type reusing a built-in id must not unregister the built-in type
+	assert.Error(t, RegisterMessageTypes([]config.RmrMessageType{{Name: "A1_CUSTOM", ID: 20010}}))
+	assert.Equal(t, 20010, MessageTypeID(A1PolicyRequest))
+	assert.Equal(t, A1PolicyRequest, MessageTypeName(20010))
+}
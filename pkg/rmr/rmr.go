@@ -22,261 +22,318 @@
 package rmr
 
 import (
-	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"strconv"
+	"time"
 
 	"gerrit.o-ran-sc.org/r/ric-plt/a1/config"
 	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/a1"
-	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/models"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/ecs"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/metrics"
 	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/policy"
 	"gerrit.o-ran-sc.org/r/ric-plt/xapp-frame/pkg/xapp"
 )
 
 const (
-	a1SourceName      = "service-ricplt-a1mediator-http"
-	a1PolicyRequest   = 20010
-	ecsServiceHost    = "http://ecs-service:8083"
-	ecsEiTypePath     = ecsServiceHost + "/A1-EI/v1/eitypes"
-	ecsEiJobPath      = ecsServiceHost + "/A1-EI/v1/eijobs/"
-	a1EiQueryAllResp  = 20014
-	a1EiCreateJobResp = 20016
-	jobCreationData   = `{"ei_job_id": %s.}`
-	DefaultSubId      = -1
+	a1SourceName    = "service-ricplt-a1mediator-http"
+	jobCreationData = `{"ei_job_id": %s.}`
+	DefaultSubId    = -1
 )
 
 type RmrSender struct {
-	rmrclient     *xapp.RMRClient
-	policyManager *policy.PolicyManager
+	transport       rmrTransport
+	policyManager   *policy.PolicyManager
+	messageVersion  int
+	workers         *workerPool
+	eiWorkers       *workerPool
+	maxSize         int
+	largeMessage    string
+	referenceTTL    time.Duration
+	queryLimiter    *queryLimiter
+	feedbackSamples int
+	ecs             *ecs.Client
 }
 
 type IRmrSender interface {
-	RmrSendToXapp(httpBodyString string, messagetype int, subid int) bool
+	RmrSendToXapp(httpBodyString string, messagetype int, subid int, transactionId string) bool
+	RmrSendToNode(httpBodyString string, messagetype int, subid int, ranName string, transactionId string) bool
 }
 
 func NewRMRSender(policyManager *policy.PolicyManager) IRmrSender {
 	config := config.ParseConfiguration()
-	RMRclient := xapp.NewRMRClientWithParams(&xapp.RMRClientParams{
-		StatDesc: "",
-		RmrData: xapp.PortData{
-
-			Name:              config.Name,
-			MaxSize:           config.MaxSize,
-			ThreadType:        config.ThreadType,
-			LowLatency:        config.LowLatency,
-			FastAck:           config.FastAck,
-			MaxRetryOnFailure: config.MaxRetryOnFailure,
-			Port:              config.Port,
-		},
-	})
+	var transport rmrTransport
+	if config.RmrTransport == FakeTransportName {
+		a1.Logger.Info("using the in process RMR transport")
+		transport = DefaultFakeTransport()
+	} else {
+		transport = newXappTransport(config)
+	}
 
 	rmrsender := &RmrSender{
-		rmrclient:     RMRclient,
-		policyManager: policyManager,
+		transport:       transport,
+		policyManager:   policyManager,
+		messageVersion:  config.PolicyMessageVersion,
+		maxSize:         config.MaxSize,
+		largeMessage:    config.LargeMessageStrategy,
+		referenceTTL:    time.Duration(config.LargeMessageReferenceTTL) * time.Second,
+		feedbackSamples: config.PolicyFeedbackSamples,
+	}
+	ecsClient, err := ecs.NewClient(config)
+	if err != nil {
+		a1.Logger.Error("invalid ECS configuration, EI messages are not forwarded : %v", err)
+	}
+	rmrsender.ecs = ecsClient
+	if policyManager != nil {
+		rmrsender.restoreReferences()
+	}
+	if config.PolicyQueryInterval > 0 {
+		rmrsender.queryLimiter = newQueryLimiter(time.Duration(config.PolicyQueryInterval) * time.Second)
+	}
+	if config.RmrWorkers > 0 {
+		rmrsender.workers = newWorkerPool(config.RmrWorkers, config.RmrQueueSize, func(job *rmrJob) {
+			rmrsender.handleMessage(job)
+		})
+		rmrsender.eiWorkers = newWorkerPool(config.RmrWorkers, config.RmrQueueSize, func(job *rmrJob) {
+			rmrsender.handleMessage(job)
+		})
+	}
+
+	if config.HandlerLivenessTimeout > 0 && config.HandlerLivenessInterval > 0 {
+		rmrsender.startLivenessMonitor(time.Duration(config.HandlerLivenessTimeout)*time.Second, time.Duration(config.HandlerLivenessInterval)*time.Second)
 	}
 
 	rmrsender.RmrRecieveStart()
 	return rmrsender
 }
 
-var RICMessageTypes = map[string]int{
-	"A1_POLICY_REQ":         20010,
-	"A1_POLICY_RESP":        20011,
-	"A1_POLICY_QUERY":       20012,
-	"A1_EI_QUERY_ALL":       20013,
-	"AI_EI_QUERY_ALL_RESP":  20014,
-	"A1_EI_CREATE_JOB":      20015,
-	"A1_EI_CREATE_JOB_RESP": 20016,
-	"A1_EI_DATA_DELIVERY":   20017,
-}
-
-func (rmr *RmrSender) GetRicMessageName(id int) (s string) {
-	for k, v := range RICMessageTypes {
-		if id == v {
-			return k
-		}
-	}
-	return
+func (rmr *RmrSender) RmrSendToXapp(httpBodyString string, messagetype int, subid int, transactionId string) bool {
+	return rmr.RmrSendToNode(httpBodyString, messagetype, subid, "", transactionId)
 }
 
-func (rmr *RmrSender) RmrSendToXapp(httpBodyString string, messagetype int, subid int) bool {
+// RmrSendToNode sends the message with the RAN node set as its Meid, so that it
+// is only routed to the xApps serving the node, and the transaction id of the
+// policy request it carries, if any, as its Xid. A message over MAX_SIZE is sent
+// as configured by LARGE_MESSAGE_STRATEGY.
+func (rmr *RmrSender) RmrSendToNode(httpBodyString string, messagetype int, subid int, ranName string, transactionId string) bool {
 
 	params := &xapp.RMRParams{}
 	params.Mtype = messagetype
 	params.SubId = subid
-	params.Xid = ""
-	params.Meid = &xapp.RMRMeid{}
+	params.Xid = transactionId
+	params.Meid = &xapp.RMRMeid{RanName: ranName}
 	params.Src = a1SourceName
 	params.PayloadLen = len([]byte(httpBodyString))
 	params.Payload = []byte(httpBodyString)
 	a1.Logger.Debug("MSG to XAPP: %s ", params.String())
 	a1.Logger.Debug("len payload %+v", len(params.Payload))
-	s := rmr.rmrclient.SendMsg(params)
+	if rmr.maxSize > 0 && params.PayloadLen > rmr.maxSize {
+		return rmr.sendLargeMessage(params)
+	}
+	s := rmr.transport.SendMsg(params)
 	a1.Logger.Debug("rmrSendToXapp: sending: %+v", s)
 	return s
 }
 
 func (rmr *RmrSender) Consume(msg *xapp.RMRParams) (err error) {
 	a1.Logger.Debug("In the Consume function")
-	id := rmr.GetRicMessageName(msg.Mtype)
-	a1.Logger.Debug("Message received: name=%s meid=%s subId=%d txid=%s len=%d", id, msg.Meid.RanName, msg.SubId, msg.Xid, msg.PayloadLen)
-
-	switch id {
-
-	case "A1_POLICY_RESP":
-		a1.Logger.Debug("Recived policy responose")
-		payload := msg.Payload
-		a1.Logger.Debug("message recieved : %s", payload)
-		var result map[string]interface{}
-		err := json.Unmarshal([]byte(payload), &result)
-		if err != nil {
-			a1.Logger.Error("Unmarshal error : %+v", err)
-			return err
-		}
-		policyTypeId := int(result["policy_type_id"].(float64))
-		policyInstanceId := result["policy_instance_id"].(string)
-		policyHandlerId := result["handler_id"].(string)
-		policyStatus := result["status"].(string)
-
-		a1.Logger.Debug("message recieved for %d and %s with status : %s", policyTypeId, policyInstanceId, policyStatus)
-		rmr.policyManager.SetPolicyInstanceStatus(policyTypeId, policyInstanceId, policyStatus)
-		err = rmr.policyManager.SendPolicyStatusNotification(policyTypeId, policyInstanceId, policyHandlerId, policyStatus)
-		if err != nil {
-			a1.Logger.Debug("failed to send policy status notification %v+", err)
-		}
+	id := MessageTypeName(msg.Mtype)
+	var ranName string
+	if msg.Meid != nil {
+		ranName = msg.Meid.RanName
+	}
+	a1.Logger.Debug("Message received: name=%s meid=%s subId=%d txid=%s len=%d", id, ranName, msg.SubId, msg.Xid, msg.PayloadLen)
 
-	case "A1_POLICY_QUERY":
-		a1.Logger.Debug("Recived policy query")
-		a1.Logger.Debug("message recieved ", msg.Payload)
-		payload := msg.Payload
-		var result map[string]interface{}
-		json.Unmarshal([]byte(payload), &result)
-		a1.Logger.Debug("message recieved : %s for %d and %d", result, result["policy_type_id"], result["policy_instance_id"])
-		policytypeid := (result["policy_type_id"].(float64))
-		instanceList, err1 := rmr.policyManager.GetAllPolicyInstance(int(policytypeid))
-		if err1 != nil {
-			a1.Logger.Error("Error : %+v", err1)
-			return err1
-		}
-		a1.Logger.Debug("instanceList ", instanceList)
-		a1.Logger.Debug("Received a query for a known policy type: %d", policytypeid)
-		for _, policyinstanceid := range instanceList {
-			policyinstance, err2 := rmr.policyManager.GetPolicyInstance(models.PolicyTypeID(policytypeid), policyinstanceid)
-			if err2 != nil {
-				a1.Logger.Error("Error : %+v", err2)
-				return err2
-			}
-			a1.Logger.Debug("policyinstance ", policyinstance.(string))
-			message := Message{}
-			rmrMessage, err1 := message.PolicyMessage(strconv.FormatInt((int64(policytypeid)), 10), string(policyinstanceid), policyinstance.(string), "CREATE")
-			if err1 != nil {
-				a1.Logger.Error("error : %v", err1)
-				return err1
-			}
-			a1.Logger.Debug("rmrMessage ", rmrMessage)
-			isSent := rmr.RmrSendToXapp(rmrMessage, a1PolicyRequest, int(policytypeid))
-			if isSent {
-				a1.Logger.Debug("rmrSendToXapp : message sent")
-			} else {
-				a1.Logger.Error("rmrSendToXapp : message not sent")
-			}
-		}
+	result, err := validateMessage(id, msg.Payload)
+	if err == unknownMessageError {
+		xapp.Logger.Error("Unknown message type '%d', discarding", msg.Mtype)
+		metrics.IncCounter(metrics.UnknownRmrMessage)
+		rmr.freeMessage(msg)
+		return nil
+	}
+	if err != nil {
+		a1.Logger.Error("rejecting invalid message %s : %v", id, err)
+		metrics.IncCounter(metrics.InvalidRmrMessage)
+		rmr.freeMessage(msg)
+		return err
+	}
 
-	case "A1_EI_QUERY_ALL":
-		a1.Logger.Debug("message recieved ", msg.Payload)
-		resp, err := http.Get(ecsEiTypePath)
-		if err != nil {
-			a1.Logger.Error("Received error while fetching health info: %v", err)
-		}
-		if resp.StatusCode != http.StatusOK {
-			a1.Logger.Warning("Received no reponse from A1-EI service1")
-		}
-		a1.Logger.Debug("response from A1-EI service : ", resp)
+	job := &rmrJob{name: id, msg: msg, result: result, received: time.Now()}
+	if rmr.workers == nil {
+		return rmr.handleMessage(job)
+	}
+	workers := rmr.workers
+	if isEiMessage(id) {
+		workers = rmr.eiWorkers
+	}
+	// a dropped policy response would leave the state of its instance behind
+	if !workers.dispatch(messageOrderingKey(id, result), job, id == A1PolicyResponse) {
+		a1.Logger.Error("dropping message %s, the queue of its worker is full", id)
+		rmr.freeMessage(msg)
+		return fmt.Errorf("queue full, message %s dropped", id)
+	}
+	return nil
+}
 
-		defer resp.Body.Close()
-		respByte, err := ioutil.ReadAll(resp.Body)
+func (rmr *RmrSender) freeMessage(msg *xapp.RMRParams) {
+	rmr.transport.Free(msg)
+	msg.Mbuf = nil
+}
 
-		if err != nil {
-			a1.Logger.Debug("error in response: %+v", respByte)
+// handleMessage processes a validated message received from an xApp
+func (rmr *RmrSender) handleMessage(job *rmrJob) (err error) {
+	id := job.name
+	defer func() {
+		metrics.Observe(metrics.RmrProcessingLatency, float64(time.Since(job.received).Milliseconds()))
+	}()
+	defer rmr.freeMessage(job.msg)
+	// a message that cannot be handled must not stop the receiver
+	defer func() {
+		if r := recover(); r != nil {
+			a1.Logger.Error("failed to handle message %s : %v", id, r)
+			metrics.IncCounter(metrics.InvalidRmrMessage)
+			err = fmt.Errorf("failed to handle message %s : %v", id, r)
 		}
+	}()
 
-		a1.Logger.Debug("response : %+v", string(respByte))
+	return messageTypes.handler(id)(rmr, job)
+}
 
-		isSent := rmr.RmrSendToXapp(string(respByte), a1EiQueryAllResp, DefaultSubId)
-		if isSent {
-			a1.Logger.Debug("rmrSendToXapp : message sent")
-		} else {
-			a1.Logger.Error("rmrSendToXapp : message not sent")
-		}
-	case "A1_EI_CREATE_JOB":
-		payload := msg.Payload
-		a1.Logger.Debug("message recieved : %s", payload)
+// handlePolicyResponse handles the status a handler reports for a policy instance
+func (rmr *RmrSender) handlePolicyResponse(job *rmrJob) error {
+	msg, result := job.msg, job.result
+	a1.Logger.Debug("Recived policy responose")
+	a1.Logger.Debug("message recieved : %s", msg.Payload)
+	policyTypeId := int(result["policy_type_id"].(float64))
+	policyInstanceId := result["policy_instance_id"].(string)
+	policyHandlerId := result["handler_id"].(string)
+	policyStatus := result["status"].(string)
+	enforceReason, _ := result["enforce_reason"].(string)
+	enforceDetail, _ := result["detail"].(string)
+	transactionId, _ := result["transaction_id"].(string)
+	revision, _ := result["revision"].(float64)
+	ranName, _ := result["ran_name"].(string)
+	if len(ranName) == 0 && msg.Meid != nil {
+		ranName = msg.Meid.RanName
+	}
 
-		var result map[string]interface{}
+	a1.Logger.Debug("message recieved for %d and %s with status : %s reason : %s", policyTypeId, policyInstanceId, policyStatus, enforceReason)
+	stale, err := rmr.policyManager.IsStaleResponse(policyTypeId, policyInstanceId, transactionId, int64(revision))
+	if err != nil {
+		a1.Logger.Error("failed to get policy transaction : %v", err)
+		return err
+	}
+	if stale {
+		a1.Logger.Warning("ignoring stale response of %s for %d and %s, transaction : %s revision : %d", policyHandlerId, policyTypeId, policyInstanceId, transactionId, int64(revision))
+		metrics.IncCounter(metrics.StalePolicyResponse)
+		return nil
+	}
+	if err = rmr.policyManager.RecordPolicyTypeHandler(policyTypeId, policyHandlerId, policyStatus); err != nil {
+		a1.Logger.Error("failed to record handler %s of policy type %d : %v", policyHandlerId, policyTypeId, err)
+	}
+	changed, err := rmr.policyManager.SetPolicyInstanceStatus(policyTypeId, policyInstanceId, policyHandlerId, ranName, policyStatus, enforceReason, enforceDetail)
+	if err != nil {
+		a1.Logger.Error("failed to set policy instance status : %v", err)
+		return err
+	}
+	if !changed {
+		return nil
+	}
+	err = rmr.policyManager.SendPolicyStatusNotification(policyTypeId, policyInstanceId, policyHandlerId, policyStatus)
+	if err != nil {
+		a1.Logger.Debug("failed to send policy status notification %v+", err)
+	}
+	return nil
+}
 
-		err := json.Unmarshal([]byte(payload), &result)
-		if err != nil {
-			a1.Logger.Error("Unmarshal error : %+v", err)
-			return err
-		}
-		a1.Logger.Debug("Unmarshaled message recieved : %s ", result)
+// eiError tells the xApps that ECS did not serve an A1-EI request
+type eiError struct {
+	Request    string `json:"request"`
+	JobId      *int64 `json:"job-id,omitempty"`
+	StatusCode int    `json:"status_code"`
+	Error      string `json:"error"`
+}
 
-		jobIdStr := strconv.FormatInt((int64(result["job-id"].(float64))), 10)
-		jsonReq, err := json.Marshal(result)
-		if err != nil {
-			a1.Logger.Error("marshal error : %v", err)
-			return err
-		}
+var ecsNotConfiguredError = errors.New("ECS is not configured")
 
-		a1.Logger.Debug("url to send to :", ecsEiJobPath+jobIdStr)
-		req, err := http.NewRequest(http.MethodPut, ecsEiJobPath+jobIdStr, bytes.NewBuffer(jsonReq))
-		if err != nil {
-			a1.Logger.Error("http error : %v", err)
-			return err
-		}
+func (rmr *RmrSender) sendEiError(request string, jobId *int64, err error) {
+	data, err := json.Marshal(eiError{Request: request, JobId: jobId, StatusCode: ecs.StatusCode(err), Error: err.Error()})
+	if err != nil {
+		a1.Logger.Error("marshal error : %v", err)
+		return
+	}
+	if !rmr.RmrSendToXapp(string(data), MessageTypeID(A1EiError), DefaultSubId, "") {
+		a1.Logger.Error("rmrSendToXapp : message not sent")
+	}
+}
 
-		req.Header.Set("Content-Type", "application/json; charset=utf-8")
-		client := &http.Client{}
-		resp, err3 := client.Do(req)
-		if err3 != nil {
-			a1.Logger.Error("error:", err3)
-			return err
-		}
+// handleEiQueryAll sends the EI types known by ECS to the xApps, or an
+// A1_EI_ERROR when ECS does not answer
+func (rmr *RmrSender) handleEiQueryAll(job *rmrJob) error {
+	msg := job.msg
+	a1.Logger.Debug("message recieved %s", msg.Payload)
+	if rmr.ecs == nil {
+		rmr.sendEiError(job.name, nil, ecsNotConfiguredError)
+		return nil
+	}
+	respByte, err := rmr.ecs.GetEiTypes()
+	if err != nil {
+		a1.Logger.Error("failed to get the EI types from ECS : %v", err)
+		rmr.sendEiError(job.name, nil, err)
+		return nil
+	}
+	a1.Logger.Debug("response : %+v", string(respByte))
 
-		defer resp.Body.Close()
-
-		a1.Logger.Debug("response status : ", resp.StatusCode)
-		if resp.StatusCode == 200 || resp.StatusCode == 201 {
-			a1.Logger.Debug("received successful response for ei-job-id : ", jobIdStr)
-			rmrData := fmt.Sprintf(jobCreationData, jobIdStr)
-			a1.Logger.Debug("rmr_Data to send: ", rmrData)
-
-			isSent := rmr.RmrSendToXapp(rmrData, a1EiCreateJobResp, DefaultSubId)
-			if isSent {
-				a1.Logger.Debug("rmrSendToXapp : message sent")
-			} else {
-				a1.Logger.Error("rmrSendToXapp : message not sent")
-			}
-		} else {
-			a1.Logger.Warning("failed to create EIJOB ")
-		}
+	isSent := rmr.RmrSendToXapp(string(respByte), MessageTypeID(A1EiQueryAllResp), DefaultSubId, "")
+	if isSent {
+		a1.Logger.Debug("rmrSendToXapp : message sent")
+	} else {
+		a1.Logger.Error("rmrSendToXapp : message not sent")
+	}
+	return nil
+}
 
-	default:
-		xapp.Logger.Error("Unknown message type '%d', discarding", msg.Mtype)
+// handleEiCreateJob creates an EI job in ECS, sending an A1_EI_ERROR to the
+// xApps when ECS does not create it
+func (rmr *RmrSender) handleEiCreateJob(job *rmrJob) error {
+	msg, result := job.msg, job.result
+	a1.Logger.Debug("message recieved : %s", msg.Payload)
+	a1.Logger.Debug("Unmarshaled message recieved : %s ", result)
+
+	jobId := int64(result["job-id"].(float64))
+	jobIdStr := strconv.FormatInt(jobId, 10)
+	jsonReq, err := json.Marshal(result)
+	if err != nil {
+		a1.Logger.Error("marshal error : %v", err)
+		return err
 	}
 
-	defer func() {
-		rmr.rmrclient.Free(msg.Mbuf)
-		msg.Mbuf = nil
-	}()
-	return
+	if rmr.ecs == nil {
+		rmr.sendEiError(job.name, &jobId, ecsNotConfiguredError)
+		return nil
+	}
+	if _, err = rmr.ecs.PutEiJob(jobIdStr, jsonReq); err != nil {
+		a1.Logger.Warning("failed to create EIJOB %s : %v", jobIdStr, err)
+		rmr.sendEiError(job.name, &jobId, err)
+		return nil
+	}
+
+	a1.Logger.Debug("received successful response for ei-job-id : %s", jobIdStr)
+	rmrData := fmt.Sprintf(jobCreationData, jobIdStr)
+	a1.Logger.Debug("rmr_Data to send: %s", rmrData)
+
+	isSent := rmr.RmrSendToXapp(rmrData, MessageTypeID(A1EiCreateJobResp), DefaultSubId, "")
+	if isSent {
+		a1.Logger.Debug("rmrSendToXapp : message sent")
+	} else {
+		a1.Logger.Error("rmrSendToXapp : message not sent")
+	}
+	return nil
 }
 
 func (rmr *RmrSender) RmrRecieveStart() {
 	a1.Logger.Debug("Inside RmrRecieveStart function ")
-	go rmr.rmrclient.Start(rmr)
+	rmr.transport.Start(rmr)
 	a1.Logger.Debug("Reciever started")
 }
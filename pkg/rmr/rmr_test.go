@@ -0,0 +1,87 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package rmr
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/config"
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/pkg/ecs"
+	"gerrit.o-ran-sc.org/r/ric-plt/xapp-frame/pkg/xapp"
+	"github.com/stretchr/testify/assert"
+)
+
+func eiJob(t *testing.T) *rmrJob {
+	payload := []byte(`{"job-id":12}`)
+	result, err := validateMessage(A1EiCreateJob, payload)
+	assert.Nil(t, err)
+	return &rmrJob{name: A1EiCreateJob, msg: &xapp.RMRParams{Payload: payload}, result: result}
+}
+
+func TestHandleEiCreateJob(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/A1-EI/v1/eijobs/12", r.URL.Path)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+	client, err := ecs.NewClient(&config.Configuration{EcsURL: server.URL, EcsAPIVersion: "v1", EcsConnectTimeout: 1, EcsRequestTimeout: 1, EcsRetryMaxAttempts: 1})
+	assert.Nil(t, err)
+	transport := NewFakeTransport()
+	sender := &RmrSender{transport: transport, ecs: client}
+	var received []*xapp.RMRParams
+	transport.Handle(MessageTypeID(A1EiCreateJobResp), func(transport *FakeTransport, params *xapp.RMRParams) {
+		received = append(received, params)
+	})
+
+	assert.Nil(t, sender.handleEiCreateJob(eiJob(t)))
+	assert.Equal(t, 1, len(received))
+}
+
+func TestHandleEiCreateJobEcsUnavailable(t *testing.T) {
+	transport := NewFakeTransport()
+	sender := &RmrSender{transport: transport}
+	var received []*xapp.RMRParams
+	transport.Handle(MessageTypeID(A1EiError), func(transport *FakeTransport, params *xapp.RMRParams) {
+		received = append(received, params)
+	})
+
+	assert.Nil(t, sender.handleEiCreateJob(eiJob(t)))
+	assert.Equal(t, 1, len(received))
+	var reply eiError
+	assert.Nil(t, json.Unmarshal(received[0].Payload, &reply))
+	assert.Equal(t, A1EiCreateJob, reply.Request)
+	assert.Equal(t, int64(12), *reply.JobId)
+	assert.Equal(t, http.StatusServiceUnavailable, reply.StatusCode)
+}
+
+func TestConsumeWithoutMeid(t *testing.T) {
+	sender := &RmrSender{transport: NewFakeTransport()}
+	payload := []byte(`{"handler_id":"xapp1"`)
+	msg := &xapp.RMRParams{Mtype: MessageTypeID(A1HandlerHeartbeat), Payload: payload, PayloadLen: len(payload)}
+
+	assert.NotPanics(t, func() {
+		assert.NotNil(t, sender.Consume(msg))
+	})
+}
@@ -0,0 +1,75 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+package rmr
+
+import (
+	"gerrit.o-ran-sc.org/r/ric-plt/a1/config"
+	"gerrit.o-ran-sc.org/r/ric-plt/xapp-frame/pkg/xapp"
+)
+
+// names of the transports selected by RMR_TRANSPORT
+const (
+	RmrTransportName  = "rmr"
+	FakeTransportName = "fake"
+)
+
+// rmrTransport carries the RMR messages between A1 and the xApps
+type rmrTransport interface {
+	// Start delivers the messages received from the xApps to the consumer
+	Start(consumer xapp.MessageConsumer)
+	SendMsg(params *xapp.RMRParams) bool
+	// Free releases the buffer of a received message
+	Free(params *xapp.RMRParams)
+}
+
+// xappTransport sends and receives the messages through the RMR library
+type xappTransport struct {
+	client *xapp.RMRClient
+}
+
+func newXappTransport(config *config.Configuration) *xappTransport {
+	return &xappTransport{
+		client: xapp.NewRMRClientWithParams(&xapp.RMRClientParams{
+			StatDesc: "",
+			RmrData: xapp.PortData{
+				Name:              config.Name,
+				MaxSize:           config.MaxSize,
+				ThreadType:        config.ThreadType,
+				LowLatency:        config.LowLatency,
+				FastAck:           config.FastAck,
+				MaxRetryOnFailure: config.MaxRetryOnFailure,
+				Port:              config.Port,
+			},
+		}),
+	}
+}
+
+func (t *xappTransport) Start(consumer xapp.MessageConsumer) {
+	go t.client.Start(consumer)
+}
+
+func (t *xappTransport) SendMsg(params *xapp.RMRParams) bool {
+	return t.client.SendMsg(params)
+}
+
+func (t *xappTransport) Free(params *xapp.RMRParams) {
+	t.client.Free(params.Mbuf)
+}
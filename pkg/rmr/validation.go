@@ -0,0 +1,140 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+package rmr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+var unknownMessageError = errors.New("unknown message type")
+
+// messageSchemas are the schemas of the payload of the messages the xApps send
+// to A1, as described in docs/a1_xapp_contract_openapi.yaml. A1_EI_QUERY_ALL
+// carries no payload and is not validated.
+var messageSchemas = map[string]*jsonschema.Schema{
+	A1PolicyResponse: jsonschema.MustCompileString("A1_POLICY_RESP.json", `{
+		"type": "object",
+		"required": ["policy_type_id", "policy_instance_id", "handler_id", "status"],
+		"properties": {
+			"policy_type_id": {"type": "integer", "minimum": 1, "maximum": 2147483647},
+			"policy_instance_id": {"type": "string", "minLength": 1},
+			"handler_id": {"type": "string", "minLength": 1},
+			"status": {"type": "string", "enum": ["OK", "ERROR", "DELETED"]},
+			"enforce_reason": {"type": "string", "enum": ["SCOPE_NOT_APPLICABLE", "STATEMENT_NOT_APPLICABLE", "OTHER_REASON"]},
+			"detail": {"type": "string"},
+			"transaction_id": {"type": "string"},
+			"revision": {"type": "integer", "minimum": 1},
+			"ran_name": {"type": "string"}
+		}
+	}`),
+	A1PolicyQuery: jsonschema.MustCompileString("A1_POLICY_QUERY.json", `{
+		"type": "object",
+		"properties": {
+			"policy_type_id": {"type": "integer", "minimum": 1, "maximum": 2147483647},
+			"policy_instance_id": {"type": "string", "minLength": 1},
+			"policy_type_ids": {"type": "array", "items": {"type": "integer", "minimum": 1, "maximum": 2147483647}},
+			"handler_id": {"type": "string"}
+		},
+		"dependencies": {"policy_instance_id": ["policy_type_id"]},
+		"not": {"required": ["policy_type_id", "policy_type_ids"]}
+	}`),
+	A1HandlerHeartbeat: jsonschema.MustCompileString("A1_HANDLER_HEARTBEAT.json", `{
+		"type": "object",
+		"required": ["handler_id"],
+		"properties": {
+			"handler_id": {"type": "string", "minLength": 1},
+			"policy_type_ids": {"type": "array", "items": {"type": "integer", "minimum": 1, "maximum": 2147483647}}
+		}
+	}`),
+	A1PolicyFeedback: jsonschema.MustCompileString("A1_POLICY_FEEDBACK.json", `{
+		"type": "object",
+		"required": ["policy_type_id", "policy_instance_id", "handler_id", "kpis"],
+		"properties": {
+			"policy_type_id": {"type": "integer", "minimum": 1, "maximum": 2147483647},
+			"policy_instance_id": {"type": "string", "minLength": 1},
+			"handler_id": {"type": "string", "minLength": 1},
+			"kpis": {"type": "object", "minProperties": 1, "additionalProperties": {"type": "number"}},
+			"measured_at": {"type": "string"},
+			"ran_name": {"type": "string"}
+		}
+	}`),
+	A1EiCreateJob: jsonschema.MustCompileString("A1_EI_CREATE_JOB.json", `{
+		"type": "object",
+		"required": ["job-id"],
+		"properties": {
+			"job-id": {"type": "integer", "minimum": 0}
+		}
+	}`),
+	A1EiUpdateJob: jsonschema.MustCompileString("A1_EI_UPDATE_JOB.json", `{
+		"type": "object",
+		"required": ["job-id"],
+		"properties": {
+			"job-id": {"type": "integer", "minimum": 0}
+		}
+	}`),
+	A1EiQueryJob: jsonschema.MustCompileString("A1_EI_QUERY_JOB.json", `{
+		"type": "object",
+		"required": ["job-id"],
+		"properties": {
+			"job-id": {"type": "integer", "minimum": 0}
+		}
+	}`),
+	A1EiDeleteJob: jsonschema.MustCompileString("A1_EI_DELETE_JOB.json", `{
+		"type": "object",
+		"required": ["job-id"],
+		"properties": {
+			"job-id": {"type": "integer", "minimum": 0}
+		}
+	}`),
+	A1EiQueryJobs: jsonschema.MustCompileString("A1_EI_QUERY_JOBS.json", `{
+		"type": "object",
+		"required": ["owner"],
+		"properties": {
+			"owner": {"type": "string", "minLength": 1},
+			"ei_type_id": {"type": "string", "minLength": 1}
+		}
+	}`),
+}
+
+// validateMessage decodes the payload of a message received from an xApp and
+// checks it against the schema of the message type. Messages without handler
+// are reported as unknown.
+func validateMessage(name string, payload []byte) (map[string]interface{}, error) {
+	if messageTypes.handler(name) == nil {
+		return nil, unknownMessageError
+	}
+	schema, ok := messageSchemas[name]
+	if !ok {
+		return nil, nil
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(payload, &result); err != nil {
+		return nil, fmt.Errorf("invalid json : %v", err)
+	}
+	if err := schema.Validate(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,68 @@
+/*
+==================================================================================
+  Copyright (c) 2022 Samsung
+
+   Licensed under the Apache License, Version 2.0 (the "License");
+   you may not use this file except in compliance with the License.
+   You may obtain a copy of the License at
+
+       http://www.apache.org/licenses/LICENSE-2.0
+
+   Unless required by applicable law or agreed to in writing, software
+   distributed under the License is distributed on an "AS IS" BASIS,
+   WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+   See the License for the specific language governing permissions and
+   limitations under the License.
+
+   This source code is part of the near-RT RIC (RAN Intelligent Controller)
+   platform project (RICP).
+==================================================================================
+*/
+
+package rmr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMessage(t *testing.T) {
+	result, err := validateMessage("A1_POLICY_RESP", []byte(`{"policy_type_id":20001,"policy_instance_id":"123456","handler_id":"xapp1","status":"OK"}`))
+	assert.Nil(t, err)
+	assert.Equal(t, "xapp1", result["handler_id"])
+
+	result, err = validateMessage("A1_POLICY_QUERY", []byte(`{"policy_type_id":20001}`))
+	assert.Nil(t, err)
+	assert.Equal(t, float64(20001), result["policy_type_id"])
+
+	result, err = validateMessage("A1_EI_QUERY_ALL", nil)
+	assert.Nil(t, err)
+	assert.Nil(t, result)
+}
+
+func TestValidateMessageUnknownFields(t *testing.T) {
+	// fields added by newer xApps are ignored
+	result, err := validateMessage("A1_POLICY_RESP", []byte(`{"policy_type_id":20001,"policy_instance_id":"123456","handler_id":"xapp1","status":"OK","extension":{"load":3}}`))
+	assert.Nil(t, err)
+	assert.Equal(t, "xapp1", result["handler_id"])
+	_, err = validateMessage("A1_HANDLER_HEARTBEAT", []byte(`{"handler_id":"xapp1","version":"1.2"}`))
+	assert.Nil(t, err)
+	_, err = validateMessage("A1_EI_QUERY_JOBS", []byte(`{"owner":"xapp1","page":1}`))
+	assert.Nil(t, err)
+}
+
+func TestValidateMessageFail(t *testing.T) {
+	_, err := validateMessage("A1_POLICY_RESP", []byte(`{"policy_type_id":"20001","policy_instance_id":"123456","handler_id":"xapp1","status":"OK"}`))
+	assert.NotNil(t, err)
+	_, err = validateMessage("A1_POLICY_RESP", []byte(`{"policy_type_id":20001,"policy_instance_id":"123456","status":"OK"}`))
+	assert.NotNil(t, err)
+	_, err = validateMessage("A1_POLICY_RESP", []byte(`{"policy_type_id":20001,"policy_instance_id":"123456","handler_id":"xapp1","status":"ENFORCED"}`))
+	assert.NotNil(t, err)
+	_, err = validateMessage("A1_POLICY_QUERY", []byte(`not json`))
+	assert.NotNil(t, err)
+	_, err = validateMessage("A1_EI_CREATE_JOB", []byte(`{"job-id":1.5}`))
+	assert.NotNil(t, err)
+	_, err = validateMessage("A1_POLICY_REQ", []byte(`{}`))
+	assert.Equal(t, unknownMessageError, err)
+}